@@ -0,0 +1,154 @@
+package apierr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/pulpfree/gdps-fs-dwnld/authz"
+	"github.com/pulpfree/gdps-fs-dwnld/graphql"
+	"github.com/pulpfree/gdps-fs-dwnld/validate"
+	pres "github.com/pulpfree/lambda-go-proxy-response"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Code string is the machine readable error code of an error response
+type Code string
+
+// Code type constants
+const (
+	CodeValidation      Code = "VALIDATION_FAILED"
+	CodeUnauthorized    Code = "UNAUTHORIZED"
+	CodeForbidden       Code = "FORBIDDEN"
+	CodeStationNotFound Code = "STATION_NOT_FOUND"
+	CodeJobNotFound     Code = "JOB_NOT_FOUND"
+	CodeUpstreamTimeout Code = "UPSTREAM_TIMEOUT"
+	CodeUpstream        Code = "UPSTREAM_ERROR"
+	CodeStorage         Code = "STORAGE_UNAVAILABLE"
+	CodeInternal        Code = "INTERNAL_ERROR"
+)
+
+// statusCodes maps each Code to its HTTP status code
+var statusCodes = map[Code]int{
+	CodeValidation:      http.StatusBadRequest,
+	CodeUnauthorized:    http.StatusUnauthorized,
+	CodeForbidden:       http.StatusForbidden,
+	CodeStationNotFound: http.StatusNotFound,
+	CodeJobNotFound:     http.StatusNotFound,
+	CodeUpstreamTimeout: http.StatusGatewayTimeout,
+	CodeUpstream:        http.StatusBadGateway,
+	CodeStorage:         http.StatusBadGateway,
+	CodeInternal:        http.StatusInternalServerError,
+}
+
+// Error struct gives err the Code it is reported to clients with
+type Error struct {
+	Code Code
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap method
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// StatusCode method returns the HTTP status code of the error
+func (e *Error) StatusCode() int {
+	if code, ok := statusCodes[e.Code]; ok {
+		return code
+	}
+	return http.StatusInternalServerError
+}
+
+// Wrap function gives err the code, a nil err is returned as nil
+func Wrap(code Code, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Code: code, Err: err}
+}
+
+// From function returns the Error in err's chain. Other errors are classified
+// by their cause, GraphQL API failures by how the API failed.
+func From(err error) *Error {
+
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+
+	var (
+		fErr  *authz.ForbiddenError
+		nfErr *graphql.NotFoundError
+		tErr  *graphql.TimeoutError
+		sErr  *graphql.StatusError
+	)
+	switch {
+	case errors.Is(err, authz.ErrNoClaims):
+		return &Error{Code: CodeUnauthorized, Err: err}
+	case errors.As(err, &fErr):
+		return &Error{Code: CodeForbidden, Err: err}
+	case errors.As(err, &nfErr):
+		return &Error{Code: CodeStationNotFound, Err: err}
+	case errors.As(err, &tErr), errors.Is(err, context.DeadlineExceeded):
+		return &Error{Code: CodeUpstreamTimeout, Err: err}
+	case errors.As(err, &sErr):
+		switch sErr.Code {
+		case http.StatusUnauthorized:
+			return &Error{Code: CodeUnauthorized, Err: err}
+		case http.StatusForbidden:
+			return &Error{Code: CodeForbidden, Err: err}
+		}
+		return &Error{Code: CodeUpstream, Err: err}
+	}
+	return &Error{Code: CodeInternal, Err: err}
+}
+
+// Response struct is the pres.Response body with the error code
+type Response struct {
+	pres.Response
+	ErrorCode Code `json:"errorCode"`
+}
+
+// ProxyRes function responds to err with the status code of its Code. It is
+// used in place of pres.ProxyRes, which responds to any error with a 500.
+func ProxyRes(err error, hdrs map[string]string) events.APIGatewayProxyResponse {
+
+	e := From(err)
+	code := e.StatusCode()
+
+	// As with JSend, fail is the client's to fix and error is ours
+	status := "fail"
+	if code >= http.StatusInternalServerError {
+		status = "error"
+		log.Error(err)
+	} else {
+		log.Warn(err)
+	}
+
+	res := Response{
+		Response: pres.Response{
+			Code:      code,
+			Message:   err.Error(),
+			Status:    status,
+			Timestamp: time.Now().Unix(),
+		},
+		ErrorCode: e.Code,
+	}
+	// Field errors are listed so each can be shown by its input
+	var fields validate.Errors
+	if errors.As(err, &fields) {
+		res.Data = fields
+	}
+
+	body, _ := json.Marshal(res)
+	return events.APIGatewayProxyResponse{Body: string(body), Headers: hdrs, StatusCode: code}
+}
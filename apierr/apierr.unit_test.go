@@ -0,0 +1,94 @@
+package apierr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/pulpfree/gdps-fs-dwnld/authz"
+	"github.com/pulpfree/gdps-fs-dwnld/graphql"
+	"github.com/pulpfree/gdps-fs-dwnld/validate"
+	"github.com/stretchr/testify/suite"
+)
+
+// UnitSuite struct
+type UnitSuite struct {
+	suite.Suite
+}
+
+// TestFrom method
+func (suite *UnitSuite) TestFrom() {
+	errs := map[Code]error{
+		CodeValidation:      Wrap(CodeValidation, errors.New("Invalid date")),
+		CodeStorage:         fmt.Errorf("saving report: %w", Wrap(CodeStorage, errors.New("s3 unavailable"))),
+		CodeStationNotFound: &graphql.NotFoundError{Section: "FuelSales", Err: errors.New("graphql: station 1 not found")},
+		CodeUpstreamTimeout: &graphql.TimeoutError{Section: "FuelSales"},
+		CodeUnauthorized:    &graphql.StatusError{Code: http.StatusUnauthorized},
+		CodeForbidden:       &graphql.StatusError{Code: http.StatusForbidden},
+		CodeUpstream:        &graphql.StatusError{Code: http.StatusServiceUnavailable},
+		CodeInternal:        errors.New("Unknown report section"),
+	}
+	for code, err := range map[Code]error{
+		CodeUnauthorized: authz.ErrNoClaims,
+		CodeForbidden:    &authz.ForbiddenError{User: "jane", Stations: []string{"1"}},
+	} {
+		suite.Equal(code, From(err).Code, "%s", err)
+	}
+	for code, err := range errs {
+		suite.Equal(code, From(err).Code, "%s", err)
+	}
+	suite.Equal(CodeUpstreamTimeout, From(context.DeadlineExceeded).Code)
+	suite.Nil(Wrap(CodeStorage, nil))
+}
+
+// TestStatusCode method
+func (suite *UnitSuite) TestStatusCode() {
+	codes := map[Code]int{
+		CodeValidation:      400,
+		CodeUnauthorized:    401,
+		CodeForbidden:       403,
+		CodeStationNotFound: 404,
+		CodeJobNotFound:     404,
+		CodeUpstreamTimeout: 504,
+		CodeStorage:         502,
+		CodeInternal:        500,
+		Code("OTHER"):       500,
+	}
+	for code, status := range codes {
+		suite.Equal(status, (&Error{Code: code}).StatusCode(), "%s", code)
+	}
+}
+
+// TestProxyRes method
+func (suite *UnitSuite) TestProxyRes() {
+	hdrs := map[string]string{"Content-Type": "application/json"}
+	res := ProxyRes(Wrap(CodeValidation, errors.New("Invalid date")), hdrs)
+	suite.Equal(http.StatusBadRequest, res.StatusCode)
+	suite.Equal(hdrs, res.Headers)
+
+	var body map[string]interface{}
+	suite.NoError(json.Unmarshal([]byte(res.Body), &body))
+	suite.Equal(float64(400), body["code"])
+	suite.Equal("fail", body["status"])
+	suite.Equal("Invalid date", body["message"])
+	suite.Equal(string(CodeValidation), body["errorCode"])
+
+	fields := validate.Errors{{Field: "date", Error: "must be YYYY-MM-DD"}, {Field: "format", Error: "unknown format ods"}}
+	res = ProxyRes(Wrap(CodeValidation, fields), hdrs)
+	suite.Equal(http.StatusBadRequest, res.StatusCode)
+	suite.Contains(res.Body, `"data":[{"field":"date","error":"must be YYYY-MM-DD"},{"field":"format","error":"unknown format ods"}]`)
+
+	res = ProxyRes(&graphql.TimeoutError{Section: "FuelSales"}, hdrs)
+	suite.Equal(http.StatusGatewayTimeout, res.StatusCode)
+	suite.NoError(json.Unmarshal([]byte(res.Body), &body))
+	suite.Equal("error", body["status"])
+	suite.Equal(string(CodeUpstreamTimeout), body["errorCode"])
+}
+
+// TestUnitSuite function
+func TestUnitSuite(t *testing.T) {
+	suite.Run(t, new(UnitSuite))
+}
@@ -0,0 +1,114 @@
+package authz
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/pulpfree/gdps-fs-dwnld/model"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// ErrNoClaims is returned when the request carries no authorizer claims
+var ErrNoClaims = errors.New("authz: no authorizer claims")
+
+// ForbiddenError struct lists the requested stations the user may not see,
+// none when the request named no stations
+type ForbiddenError struct {
+	User     string
+	Stations []string
+}
+
+func (e *ForbiddenError) Error() string {
+	if len(e.Stations) == 0 {
+		return fmt.Sprintf("authz: user %s may not make a request without stations", e.User)
+	}
+	return fmt.Sprintf("authz: user %s may not view station %s", e.User, strings.Join(e.Stations, ", "))
+}
+
+// Policy struct maps Cognito groups and attributes to the stations their
+// users may see. It is read from the file named by the AuthPolicyFile config value.
+type Policy struct {
+	AdminGroups      []string            `yaml:"AdminGroups"`      // groups that may see every station
+	Groups           map[string][]string `yaml:"Groups"`           // group to station IDs or StationGroups names
+	StationAttribute string              `yaml:"StationAttribute"` // optional claim listing station IDs, e.g. custom:stations
+}
+
+// Load function reads the policy file at path. Names of stationGroups given
+// in place of station IDs are replaced with the group's stations.
+func Load(path string, stationGroups map[string][]string) (p *Policy, err error) {
+
+	file, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	p = &Policy{}
+	if err = yaml.UnmarshalStrict(file, p); err != nil {
+		return nil, fmt.Errorf("%s: %s", path, err)
+	}
+
+	for group, ids := range p.Groups {
+		var stations []string
+		for _, id := range ids {
+			if members, ok := stationGroups[id]; ok {
+				stations = append(stations, members...)
+				continue
+			}
+			stations = append(stations, id)
+		}
+		p.Groups[group] = stations
+	}
+	return p, err
+}
+
+// Authorize method checks the user of claims may see every station of req.
+// Admins may see any station, other users are refused requests naming none.
+func (p *Policy) Authorize(claims map[string]interface{}, req *model.Request) error {
+
+	if len(claims) == 0 {
+		return ErrNoClaims
+	}
+	u := userFrom(claims, p.StationAttribute)
+	if p.isAdmin(u) {
+		return nil
+	}
+
+	allowed := make(map[string]bool)
+	for _, id := range u.stations {
+		allowed[strings.ToLower(id)] = true
+	}
+	for _, g := range u.groups {
+		for _, id := range p.Groups[g] {
+			allowed[strings.ToLower(id)] = true
+		}
+	}
+
+	ids := req.StationList()
+	if len(ids) == 0 {
+		return &ForbiddenError{User: u.name}
+	}
+	var refused []string
+	for _, id := range ids {
+		if !allowed[strings.ToLower(id)] {
+			refused = append(refused, id)
+		}
+	}
+	if len(refused) > 0 {
+		return &ForbiddenError{User: u.name, Stations: refused}
+	}
+	return nil
+}
+
+// isAdmin method reports whether u is in one of the admin groups
+func (p *Policy) isAdmin(u user) bool {
+	for _, g := range u.groups {
+		for _, admin := range p.AdminGroups {
+			if g == admin {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package authz
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/pulpfree/gdps-fs-dwnld/model"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	policyFile = "testdata/policy.yaml"
+	stationID  = "d03224a7-f1df-4863-bcaa-5c6e61af11fc"
+	eastID     = "449d51e8-7e30-4ea2-8ba0-4f6bd3fbbf1e"
+	westID     = "8a1ec3b1-4d0c-4a3b-9a23-6b1f5d6e2c7a"
+)
+
+// UnitSuite struct
+type UnitSuite struct {
+	suite.Suite
+	policy *Policy
+}
+
+// SetupTest method
+func (suite *UnitSuite) SetupTest() {
+	var err error
+	suite.policy, err = Load(policyFile, map[string][]string{"east": {stationID, eastID}})
+	suite.Require().NoError(err)
+}
+
+// TestLoad method
+func (suite *UnitSuite) TestLoad() {
+	suite.Equal([]string{"admin"}, suite.policy.AdminGroups)
+	suite.Equal([]string{stationID, eastID}, suite.policy.Groups["east-managers"], "Expected station group expanded")
+	suite.Equal([]string{stationID}, suite.policy.Groups["station-owner"])
+
+	_, err := Load("testdata/missing.yaml", nil)
+	suite.Error(err)
+	_, err = Load("../config/policy.yaml", nil)
+	suite.NoError(err, "Expected the deployed policy to load")
+}
+
+// TestAuthorize method
+func (suite *UnitSuite) TestAuthorize() {
+	single := &model.Request{StationID: stationID}
+	group := &model.Request{StationIDs: []string{stationID, eastID}}
+	west := &model.Request{StationID: westID}
+
+	admin := map[string]interface{}{"cognito:username": "root", "cognito:groups": "admin"}
+	for _, req := range []*model.Request{single, group, west} {
+		suite.NoError(suite.policy.Authorize(admin, req), "Expected admins to see every station")
+	}
+
+	manager := map[string]interface{}{"cognito:username": "jane", "cognito:groups": "[staff east-managers]"}
+	suite.NoError(suite.policy.Authorize(manager, single))
+	suite.NoError(suite.policy.Authorize(manager, group))
+	suite.NoError(suite.policy.Authorize(manager, &model.Request{StationID: "449D51E8-7E30-4EA2-8BA0-4F6BD3FBBF1E"}))
+
+	err := suite.policy.Authorize(manager, &model.Request{StationIDs: []string{eastID, westID}})
+	var fErr *ForbiddenError
+	suite.True(errors.As(err, &fErr), "Expected ForbiddenError")
+	suite.Equal([]string{westID}, fErr.Stations)
+	suite.Equal("authz: user jane may not view station "+westID, err.Error())
+
+	owner := map[string]interface{}{"sub": "1234", "cognito:groups": "station-owner", "custom:stations": westID + ", " + eastID}
+	suite.NoError(suite.policy.Authorize(owner, group), "Expected group and attribute stations combined")
+	suite.NoError(suite.policy.Authorize(owner, west))
+
+	err = suite.policy.Authorize(manager, &model.Request{})
+	suite.True(errors.As(err, &fErr), "Expected ForbiddenError without stations")
+	suite.Empty(fErr.Stations)
+	suite.Equal("authz: user jane may not make a request without stations", err.Error())
+	suite.NoError(suite.policy.Authorize(admin, &model.Request{}))
+
+	nobody := map[string]interface{}{"cognito:username": "joe"}
+	suite.Error(suite.policy.Authorize(nobody, single))
+
+	suite.Equal(ErrNoClaims, suite.policy.Authorize(nil, single))
+}
+
+// TestClaims method
+func (suite *UnitSuite) TestClaims() {
+	rc := events.APIGatewayProxyRequestContext{Authorizer: map[string]interface{}{
+		"claims": map[string]interface{}{"cognito:username": "jane", "cognito:groups": "admin,staff"},
+	}}
+	claims := Claims(rc)
+	suite.Equal("jane", claims["cognito:username"])
+	suite.Nil(Claims(events.APIGatewayProxyRequestContext{}))
+
+	lists := map[string]interface{}{
+		"admin,staff":   "admin,staff",
+		"[admin staff]": "[admin staff]",
+		"array":         []interface{}{"admin", "staff"},
+		"strings":       []string{"admin", " staff "},
+	}
+	for nm, v := range lists {
+		suite.Equal([]string{"admin", "staff"}, claimList(v), nm)
+	}
+	suite.Nil(claimList(nil))
+}
+
+// TestUnitSuite function
+func TestUnitSuite(t *testing.T) {
+	suite.Run(t, new(UnitSuite))
+}
@@ -0,0 +1,76 @@
+package authz
+
+import (
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// Cognito claim names
+const (
+	claimGroups   = "cognito:groups"
+	claimUsername = "cognito:username"
+	claimSubject  = "sub"
+)
+
+// user struct is the caller as described by their token claims
+type user struct {
+	name     string
+	groups   []string
+	stations []string
+}
+
+// Claims function returns the Cognito authorizer claims of the request
+func Claims(rc events.APIGatewayProxyRequestContext) map[string]interface{} {
+	claims, _ := rc.Authorizer["claims"].(map[string]interface{})
+	return claims
+}
+
+// userFrom function reads the user from claims. The stations listed in
+// stationAttr are added to those of the user's groups.
+func userFrom(claims map[string]interface{}, stationAttr string) (u user) {
+
+	u.name = claimString(claims, claimUsername)
+	if u.name == "" {
+		u.name = claimString(claims, claimSubject)
+	}
+	u.groups = claimList(claims[claimGroups])
+	if stationAttr != "" {
+		u.stations = claimList(claims[stationAttr])
+	}
+	return u
+}
+
+func claimString(claims map[string]interface{}, name string) string {
+	s, _ := claims[name].(string)
+	return s
+}
+
+// claimList function splits a list claim. API Gateway passes lists as
+// strings, either comma separated or bracketed and space separated,
+// e.g. "[admin east]", while tests and other authorizers may pass arrays.
+func claimList(v interface{}) (res []string) {
+
+	var items []string
+	switch c := v.(type) {
+	case string:
+		items = strings.FieldsFunc(strings.Trim(c, "[]"), func(r rune) bool {
+			return r == ',' || r == ' '
+		})
+	case []string:
+		items = c
+	case []interface{}:
+		for _, item := range c {
+			if s, ok := item.(string); ok {
+				items = append(items, s)
+			}
+		}
+	}
+
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			res = append(res, item)
+		}
+	}
+	return res
+}
@@ -0,0 +1,80 @@
+package awsservices
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/pulpfree/gdps-fs-dwnld/config"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// DynamoService struct
+type DynamoService struct {
+	cfg *config.Config
+	db  *dynamodb.DynamoDB
+}
+
+// NewDynamo function connects to DynamoDB, or to cfg.DynamoEndpoint when set
+func NewDynamo(cfg *config.Config) (serv *DynamoService, err error) {
+
+	awsCfg := &aws.Config{
+		Region: aws.String(cfg.AWSRegion),
+	}
+	if cfg.DynamoEndpoint != "" {
+		awsCfg.Endpoint = aws.String(cfg.DynamoEndpoint)
+	}
+
+	sess, err := session.NewSession(awsCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	serv = &DynamoService{
+		cfg: cfg,
+		db:  dynamodb.New(sess),
+	}
+	return serv, err
+}
+
+// PutItem method writes item to table, replacing any item with the same key
+func (d *DynamoService) PutItem(ctx context.Context, table string, item interface{}) (err error) {
+
+	av, err := dynamodbattribute.MarshalMap(item)
+	if err != nil {
+		return err
+	}
+	_, err = d.db.PutItemWithContext(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(table),
+		Item:      av,
+	})
+	if err != nil {
+		log.Errorf("Failed to put item in %s: %s", table, err.Error())
+	}
+	return err
+}
+
+// GetItem method reads the item of table whose string key attribute is value
+// into item. found is false when there is no such item.
+func (d *DynamoService) GetItem(ctx context.Context, table, key, value string, item interface{}) (found bool, err error) {
+
+	out, err := d.db.GetItemWithContext(ctx, &dynamodb.GetItemInput{
+		TableName:      aws.String(table),
+		ConsistentRead: aws.Bool(true),
+		Key: map[string]*dynamodb.AttributeValue{
+			key: {S: aws.String(value)},
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+	if out.Item == nil {
+		return false, nil
+	}
+
+	err = dynamodbattribute.UnmarshalMap(out.Item, item)
+	return err == nil, err
+}
@@ -0,0 +1,55 @@
+package awsservices
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/pulpfree/gdps-fs-dwnld/config"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// LambdaService struct
+type LambdaService struct {
+	cfg *config.Config
+	svc *lambda.Lambda
+}
+
+// NewLambda function
+func NewLambda(cfg *config.Config) (serv *LambdaService, err error) {
+
+	sess, err := session.NewSession(&aws.Config{
+		Region: aws.String(cfg.AWSRegion),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	serv = &LambdaService{
+		cfg: cfg,
+		svc: lambda.New(sess),
+	}
+	return serv, err
+}
+
+// InvokeAsync method queues a call of function with payload encoded as JSON,
+// returning once the call is queued
+func (l *LambdaService) InvokeAsync(ctx context.Context, function string, payload interface{}) (err error) {
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	_, err = l.svc.InvokeWithContext(ctx, &lambda.InvokeInput{
+		FunctionName:   aws.String(function),
+		InvocationType: aws.String(lambda.InvocationTypeEvent),
+		Payload:        body,
+	})
+	if err != nil {
+		log.Errorf("Failed to invoke %s: %s", function, err.Error())
+	}
+	return err
+}
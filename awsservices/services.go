@@ -1,10 +1,13 @@
 package awsservices
 
 import (
-	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
@@ -37,43 +40,94 @@ func NewS3(cfg *config.Config) (serv *S3Service, err error) {
 }
 
 // PutFile method
-func (s *S3Service) PutFile(prefix string, file *bytes.Buffer) (key string, err error) {
+func (s *S3Service) PutFile(ctx context.Context, key string, body io.Reader, contentType, disposition string) (err error) {
 
 	uploader := s3manager.NewUploader(s.session)
-	_, err = uploader.Upload(&s3manager.UploadInput{
+	_, err = uploader.UploadWithContext(ctx, &s3manager.UploadInput{
 		Bucket:             aws.String(s.cfg.S3Bucket),
-		Key:                aws.String(prefix),
-		Body:               file,
-		ContentType:        aws.String("application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"),
-		ContentDisposition: aws.String("attachment"),
+		Key:                aws.String(key),
+		Body:               body,
+		ContentType:        aws.String(contentType),
+		ContentDisposition: aws.String(disposition),
 	})
 	if err != nil {
-		return "", err
+		log.Errorf("Failed to upload file: %s", err.Error())
+		return err
 	}
 
-	return prefix, nil
+	return err
 }
 
-// GetSignedURL method
-func (s *S3Service) GetSignedURL(fileObject string, file *bytes.Buffer) (signedURL string, err error) {
+// GetFile method
+func (s *S3Service) GetFile(ctx context.Context, key string) (body []byte, err error) {
 
-	_, err = s.PutFile(fileObject, file)
+	svc := s3.New(s.session)
+	out, err := svc.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.cfg.S3Bucket),
+		Key:    aws.String(key),
+	})
 	if err != nil {
-		log.Errorf("Failed to upload file: %s", err.Error())
-		return "", err
+		return nil, err
 	}
+	defer out.Body.Close()
+
+	return ioutil.ReadAll(out.Body)
+}
+
+// HasFile method
+func (s *S3Service) HasFile(ctx context.Context, key string) (exists bool, err error) {
+
+	svc := s3.New(s.session)
+	_, err = svc.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(s.cfg.S3Bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		if IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, err
+}
+
+// DeleteFile method
+func (s *S3Service) DeleteFile(ctx context.Context, key string) (err error) {
+
+	svc := s3.New(s.session)
+	_, err = svc.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.cfg.S3Bucket),
+		Key:    aws.String(key),
+	})
+	return err
+}
+
+// SignURL method
+func (s *S3Service) SignURL(key string, expiry time.Duration) (signedURL string, err error) {
 
 	svc := s3.New(s.session)
 	req, _ := svc.GetObjectRequest(&s3.GetObjectInput{
 		Bucket: aws.String(s.cfg.S3Bucket),
-		Key:    aws.String(fileObject),
+		Key:    aws.String(key),
 	})
 
-	urlStr, err := req.Presign(15 * time.Minute)
+	signedURL, err = req.Presign(expiry)
 	if err != nil {
 		log.Errorf("Failed to sign request: %s", err.Error())
 		return "", err
 	}
 
-	return urlStr, err
+	return signedURL, err
+}
+
+// IsNotFound function reports whether err is a missing key or object error
+func IsNotFound(err error) bool {
+	if aErr, ok := err.(awserr.Error); ok {
+		switch aErr.Code() {
+		case s3.ErrCodeNoSuchKey, "NotFound":
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/pulpfree/gdps-fs-dwnld/config"
+	"github.com/pulpfree/gdps-fs-dwnld/fuelsale"
+	"github.com/pulpfree/gdps-fs-dwnld/model"
+	"github.com/pulpfree/gdps-fs-dwnld/validate"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const usage = `Usage: gdps-report (--month YYYY-MM | --from YYYY-MM-DD --to YYYY-MM-DD) (--station ID | --stations FILE) [options]
+       gdps-report --serve [--config FILE]
+
+Generates station reports and writes them to disk. With --serve, serves the
+reports of the local storage backend at LocalStorageURL instead.
+
+Options:
+`
+
+// options struct
+type options struct {
+	configPath   string
+	noCharts     bool
+	format       string
+	from         string
+	month        string
+	out          string
+	sections     string
+	serve        bool
+	station      string
+	stationsFile string
+	to           string
+	token        string
+}
+
+func main() {
+
+	opts := parseFlags()
+
+	cfg := &config.Config{DefaultsFilePath: opts.configPath}
+	if err := cfg.Load(); err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sig
+		cancel()
+	}()
+
+	if opts.serve {
+		if err := serve(ctx, cfg); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	stations, err := stationIDs(opts)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// Fail before any GraphQL work rather than when saving
+	if err = os.MkdirAll(opts.out, 0755); err != nil {
+		log.Fatal(err)
+	}
+
+	failed := 0
+	for _, stationID := range stations {
+		fp, err := createReport(ctx, cfg, opts, stationID)
+		if err != nil {
+			log.Errorf("Station %s failed: %s", stationID, err)
+			failed++
+			if ctx.Err() != nil {
+				break
+			}
+			continue
+		}
+		fmt.Println(fp)
+	}
+
+	if failed > 0 {
+		log.Errorf("%d of %d reports failed", failed, len(stations))
+		os.Exit(1)
+	}
+}
+
+// createReport function builds the report for stationID and saves it to opts.out
+func createReport(ctx context.Context, cfg *config.Config, opts *options, stationID string) (fp string, err error) {
+
+	input := &model.RequestInput{
+		Format:    opts.format,
+		StationID: stationID,
+	}
+	if opts.noCharts {
+		input.Charts = new(bool)
+	}
+	if opts.sections != "" {
+		input.Sections = strings.Split(opts.sections, ",")
+	}
+	if opts.month != "" {
+		input.Date = opts.month + "-01"
+	} else {
+		input.StartDate, input.EndDate = opts.from, opts.to
+	}
+	req, err := validate.RequestInput(input)
+	if err != nil {
+		return "", err
+	}
+
+	report, err := fuelsale.ForRequest(req, cfg, opts.token, nil)
+	if err != nil {
+		return "", err
+	}
+	if err = report.Create(ctx); err != nil {
+		return "", err
+	}
+
+	return report.SaveToDisk(opts.out)
+}
+
+// parseFlags function
+func parseFlags() *options {
+
+	opts := new(options)
+	flag.StringVar(&opts.configPath, "config", "", "path to defaults.yaml (default ./defaults.yaml)")
+	flag.StringVar(&opts.format, "format", "", "output format, xlsx, csv, pdf or json (default xlsx)")
+	flag.StringVar(&opts.from, "from", "", "first day of a date range, YYYY-MM-DD")
+	flag.BoolVar(&opts.noCharts, "no-charts", false, "leave the charts out of xlsx workbooks")
+	flag.StringVar(&opts.month, "month", "", "report month, YYYY-MM")
+	flag.StringVar(&opts.out, "out", ".", "output directory")
+	flag.StringVar(&opts.sections, "sections", "", "comma separated report sections (default all)")
+	flag.BoolVar(&opts.serve, "serve", false, "serve reports stored with StorageBackend local at LocalStorageURL")
+	flag.StringVar(&opts.station, "station", "", "station ID")
+	flag.StringVar(&opts.stationsFile, "stations", "", "file of station IDs, one per line")
+	flag.StringVar(&opts.to, "to", "", "last day of a date range, YYYY-MM-DD")
+	flag.StringVar(&opts.token, "token", os.Getenv("GDPS_TOKEN"), "API auth token (default $GDPS_TOKEN)")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	return opts
+}
+
+// stationIDs function returns the stations requested on the command line
+func stationIDs(opts *options) (ids []string, err error) {
+
+	if opts.month != "" && (opts.from != "" || opts.to != "") {
+		return nil, errors.New("Use only one of --month or --from and --to")
+	}
+	if opts.month == "" && (opts.from == "" || opts.to == "") {
+		return nil, errors.New("Missing --month, or --from and --to")
+	}
+	if opts.station != "" && opts.stationsFile != "" {
+		return nil, errors.New("Use only one of --station or --stations")
+	}
+	if opts.station != "" {
+		return []string{opts.station}, nil
+	}
+	if opts.stationsFile == "" {
+		return nil, errors.New("Missing --station or --stations")
+	}
+
+	file, err := os.Open(opts.stationsFile)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	ids, err = readStationIDs(file)
+	if err == nil && len(ids) == 0 {
+		err = fmt.Errorf("No station IDs found in %s", opts.stationsFile)
+	}
+	return ids, err
+}
+
+// readStationIDs function reads one ID per line, ignoring blank lines and # comments
+func readStationIDs(r io.Reader) (ids []string, err error) {
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		if line = strings.TrimSpace(line); line != "" {
+			ids = append(ids, line)
+		}
+	}
+	return ids, scanner.Err()
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// UnitSuite struct
+type UnitSuite struct {
+	suite.Suite
+}
+
+// TestReadStationIDs method
+func (suite *UnitSuite) TestReadStationIDs() {
+	ids, err := readStationIDs(strings.NewReader(`
+# Region north
+d03224a7-f1df-4863-bcaa-5c6e61af11fc
+  449d51e8-23ab-4102-8385-57eb9f31f22f  # Second Station
+
+`))
+	suite.NoError(err)
+	suite.Equal([]string{
+		"d03224a7-f1df-4863-bcaa-5c6e61af11fc",
+		"449d51e8-23ab-4102-8385-57eb9f31f22f",
+	}, ids)
+}
+
+// TestStationIDs method
+func (suite *UnitSuite) TestStationIDs() {
+	_, err := stationIDs(&options{station: "1"})
+	suite.Error(err, "Expected missing month error")
+
+	_, err = stationIDs(&options{month: "2018-08"})
+	suite.Error(err, "Expected missing station error")
+
+	_, err = stationIDs(&options{month: "2018-08", station: "1", stationsFile: "ids.txt"})
+	suite.Error(err, "Expected conflicting flags error")
+
+	_, err = stationIDs(&options{from: "2018-07-01", station: "1"})
+	suite.Error(err, "Expected incomplete range error")
+
+	_, err = stationIDs(&options{month: "2018-08", from: "2018-07-01", to: "2018-09-30", station: "1"})
+	suite.Error(err, "Expected conflicting flags error")
+
+	ids, err := stationIDs(&options{month: "2018-08", station: "1"})
+	suite.NoError(err)
+	suite.Equal([]string{"1"}, ids)
+
+	ids, err = stationIDs(&options{from: "2018-07-01", to: "2018-09-30", station: "1"})
+	suite.NoError(err)
+	suite.Equal([]string{"1"}, ids)
+}
+
+// TestServeAddr method
+func (suite *UnitSuite) TestServeAddr() {
+	addrs := map[string]string{
+		"http://localhost:8080/files": ":8080",
+		"http://reports.local/files":  ":80",
+		"https://reports.local":       ":443",
+	}
+	for baseURL, addr := range addrs {
+		got, err := serveAddr(baseURL)
+		suite.NoError(err)
+		suite.Equal(addr, got, baseURL)
+	}
+	_, err := serveAddr("/files")
+	suite.Error(err)
+}
+
+// TestUnitSuite function
+func TestUnitSuite(t *testing.T) {
+	suite.Run(t, new(UnitSuite))
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pulpfree/gdps-fs-dwnld/config"
+	"github.com/pulpfree/gdps-fs-dwnld/storage"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// serve function serves the reports of the local storage backend at
+// LocalStorageURL, the links signed for them, until ctx is done
+func serve(ctx context.Context, cfg *config.Config) error {
+
+	if cfg.StorageBackend != storage.BackendLocal {
+		return errors.New("--serve requires StorageBackend local")
+	}
+	store, err := storage.NewLocal(cfg.LocalStorageDir, cfg.LocalStorageURL, cfg.LocalStorageSecret)
+	if err != nil {
+		return err
+	}
+	addr, err := serveAddr(cfg.LocalStorageURL)
+	if err != nil {
+		return err
+	}
+
+	srv := &http.Server{Addr: addr, Handler: store.Handler()}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	log.Infof("Serving %s from %s on %s", cfg.LocalStorageURL, cfg.LocalStorageDir, addr)
+	if err = srv.ListenAndServe(); err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// serveAddr function returns the address to listen on for links to baseURL
+func serveAddr(baseURL string) (string, error) {
+
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Host == "" {
+		return "", errors.New("Invalid LocalStorageURL: " + baseURL)
+	}
+	port := u.Port()
+	if port == "" {
+		port = "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
+	}
+	return net.JoinHostPort("", port), nil
+}
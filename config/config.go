@@ -7,6 +7,8 @@ import (
 	"os"
 	"path"
 	"reflect"
+	"strconv"
+	"time"
 
 	yaml "gopkg.in/yaml.v2"
 )
@@ -19,17 +21,61 @@ type Config struct {
 
 // defaults struct
 type defaults struct {
-	AWSRegion  string `yaml:"AWSRegion"`
-	S3Bucket   string `yaml:"S3Bucket"`
-	GraphqlURI string `yaml:"GraphqlURI"`
-	Stage      string `yaml:"Stage"`
+	AuthPolicyFile     string              `yaml:"AuthPolicyFile"`
+	AWSRegion          string              `yaml:"AWSRegion"`
+	CacheFreezeDays    int                 `yaml:"CacheFreezeDays"`
+	DynamoEndpoint     string              `yaml:"DynamoEndpoint"`
+	FetchConcurrency   int                 `yaml:"FetchConcurrency"`
+	S3Bucket           string              `yaml:"S3Bucket"`
+	GraphqlBatch       bool                `yaml:"GraphqlBatch"`
+	GraphqlMaxAttempts int                 `yaml:"GraphqlMaxAttempts"`
+	GraphqlRetryBase   int                 `yaml:"GraphqlRetryBase"`
+	GraphqlRetryMax    int                 `yaml:"GraphqlRetryMax"`
+	GraphqlTimeout     int                 `yaml:"GraphqlTimeout"`
+	GraphqlURI         string              `yaml:"GraphqlURI"`
+	JobFunction        string              `yaml:"JobFunction"`
+	JobStore           string              `yaml:"JobStore"`
+	JobTable           string              `yaml:"JobTable"`
+	JobTTL             int                 `yaml:"JobTTL"`
+	LocalStorageDir    string              `yaml:"LocalStorageDir"`
+	LocalStorageSecret string              `yaml:"LocalStorageSecret"`
+	LocalStorageURL    string              `yaml:"LocalStorageURL"`
+	ReportCache        bool                `yaml:"ReportCache"`
+	Stage              string              `yaml:"Stage"`
+	StationGroups      map[string][]string `yaml:"StationGroups"`
+	StationLookup      bool                `yaml:"StationLookup"`
+	StorageBackend     string              `yaml:"StorageBackend"`
+	URLExpiry          int                 `yaml:"URLExpiry"`
+	URLExpiryMax       int                 `yaml:"URLExpiryMax"`
 }
 
 type config struct {
-	AWSRegion  string
-	S3Bucket   string
-	GraphqlURI string
-	Stage      StageEnvironment
+	AuthPolicyFile     string // station access policy, none when empty
+	AWSRegion          string
+	CacheFreezeDays    int    // days after month end before a report is reused
+	DynamoEndpoint     string // optional, e.g. a DynamoDB Local url
+	FetchConcurrency   int
+	S3Bucket           string
+	GraphqlBatch       bool // fetch all sections in one query
+	GraphqlMaxAttempts int
+	GraphqlRetryBase   time.Duration // initial retry backoff
+	GraphqlRetryMax    time.Duration // backoff ceiling
+	GraphqlTimeout     time.Duration // per-query deadline
+	GraphqlURI         string
+	JobFunction        string        // lambda function running report jobs
+	JobStore           string        // dynamodb or memory
+	JobTable           string        // DynamoDB table of report jobs
+	JobTTL             time.Duration // how long job records are kept
+	LocalStorageDir    string
+	LocalStorageSecret string
+	LocalStorageURL    string
+	ReportCache        bool
+	Stage              StageEnvironment
+	StationGroups      map[string][]string // group name to station IDs
+	StationLookup      bool                // check requested stations exist before reporting
+	StorageBackend     string              // s3 or local
+	URLExpiry          time.Duration       // default signed url lifetime
+	URLExpiryMax       time.Duration       // longest lifetime a request may ask for
 }
 
 // Dynamo struct
@@ -68,7 +114,7 @@ func (c *Config) Load() (err error) {
 
 	c.setFinal()
 
-	return err
+	return c.validate()
 }
 
 // GetStageEnv method
@@ -89,6 +135,8 @@ func (c *Config) setDefaults() (err error) {
 		return err
 	}
 
+	// Start from empty defaults so maps aren't merged with an earlier Load
+	defs = &defaults{}
 	err = yaml.Unmarshal([]byte(file), &defs)
 	if err != nil {
 		return err
@@ -133,7 +181,9 @@ func (c *Config) setEnvVars() (err error) {
 	for i := 0; i < vals.NumField(); i++ {
 		nm := vals.Type().Field(i).Name
 		if e := os.Getenv(nm); e != "" {
-			vals.Field(i).SetString(e)
+			if err = setField(vals.Field(i), e); err != nil {
+				return fmt.Errorf("Invalid value for environment variable %s: %s", nm, err)
+			}
 		}
 		// If field is Stage, validate and return error if required
 		if nm == "Stage" {
@@ -147,9 +197,75 @@ func (c *Config) setEnvVars() (err error) {
 	return err
 }
 
+// validate method rejects settings that would only fail once a request is made
+func (c *Config) validate() error {
+
+	// Anyone could sign links to the stored reports with an empty secret
+	if c.StorageBackend == "local" && c.LocalStorageSecret == "" {
+		return errors.New("Missing LocalStorageSecret, required with StorageBackend local")
+	}
+	return nil
+}
+
 // Copies required fields from the defaults to the Config struct
 func (c *Config) setFinal() {
+	c.AuthPolicyFile = defs.AuthPolicyFile
+	if c.AuthPolicyFile != "" && !path.IsAbs(c.AuthPolicyFile) {
+		// Relative to defaults.yaml, which it is deployed with
+		c.AuthPolicyFile = path.Join(path.Dir(c.DefaultsFilePath), c.AuthPolicyFile)
+	}
 	c.AWSRegion = defs.AWSRegion
+	c.CacheFreezeDays = defs.CacheFreezeDays
+	c.DynamoEndpoint = defs.DynamoEndpoint
+	c.FetchConcurrency = defs.FetchConcurrency
+	c.GraphqlBatch = defs.GraphqlBatch
+	c.GraphqlMaxAttempts = defs.GraphqlMaxAttempts
+	c.GraphqlRetryBase = time.Duration(defs.GraphqlRetryBase) * time.Millisecond
+	c.GraphqlRetryMax = time.Duration(defs.GraphqlRetryMax) * time.Millisecond
+	c.GraphqlTimeout = time.Duration(defs.GraphqlTimeout) * time.Second
 	c.GraphqlURI = defs.GraphqlURI
+	c.JobFunction = defs.JobFunction
+	c.JobStore = defs.JobStore
+	c.JobTable = defs.JobTable
+	c.JobTTL = time.Duration(defs.JobTTL) * time.Hour
+	c.LocalStorageDir = defs.LocalStorageDir
+	c.LocalStorageSecret = defs.LocalStorageSecret
+	c.LocalStorageURL = defs.LocalStorageURL
+	c.ReportCache = defs.ReportCache
 	c.S3Bucket = defs.S3Bucket
+	c.StationGroups = defs.StationGroups
+	c.StationLookup = defs.StationLookup
+	c.StorageBackend = defs.StorageBackend
+	c.URLExpiry = time.Duration(defs.URLExpiry) * time.Minute
+	c.URLExpiryMax = time.Duration(defs.URLExpiryMax) * time.Minute
+}
+
+// setField sets a defaults field from its string environment value
+func setField(field reflect.Value, val string) (err error) {
+
+	switch field.Kind() {
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(val)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Map:
+		// Maps are given as YAML, e.g. {east: [id1, id2]}
+		m := reflect.New(field.Type())
+		if err = yaml.Unmarshal([]byte(val), m.Interface()); err != nil {
+			return err
+		}
+		field.Set(m.Elem())
+	default:
+		field.SetString(val)
+	}
+
+	return err
 }
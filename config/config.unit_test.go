@@ -0,0 +1,97 @@
+package config
+
+import (
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// UnitSuite struct
+type UnitSuite struct {
+	suite.Suite
+}
+
+// SetupTest method
+func (suite *UnitSuite) SetupTest() {
+	os.Setenv("Stage", "test")
+}
+
+// TearDownTest method
+func (suite *UnitSuite) TearDownTest() {
+	os.Unsetenv("URLExpiry")
+	os.Unsetenv("GraphqlMaxAttempts")
+	os.Unsetenv("StationGroups")
+	os.Unsetenv("AuthPolicyFile")
+	os.Unsetenv("StorageBackend")
+	os.Unsetenv("LocalStorageSecret")
+}
+
+// TestLoad method
+func (suite *UnitSuite) TestLoad() {
+	c := &Config{DefaultsFilePath: defaultFileName}
+	err := c.Load()
+	suite.NoError(err)
+	suite.Equal(TestEnv, c.GetStageEnv())
+	suite.Equal(15*time.Minute, c.URLExpiry)
+	suite.Equal(4*time.Second, c.GraphqlTimeout)
+	suite.True(c.StationLookup)
+	suite.Empty(c.AuthPolicyFile, "Expected station access unchecked by default")
+}
+
+// TestAuthPolicyFile method
+func (suite *UnitSuite) TestAuthPolicyFile() {
+	dir, _ := os.Getwd()
+	os.Setenv("AuthPolicyFile", "policy.yaml")
+	c := &Config{DefaultsFilePath: path.Join(dir, defaultFileName)}
+	suite.NoError(c.Load())
+	suite.Equal(path.Join(dir, "policy.yaml"), c.AuthPolicyFile)
+
+	os.Setenv("AuthPolicyFile", "/etc/gdps/policy.yaml")
+	suite.NoError(c.Load())
+	suite.Equal("/etc/gdps/policy.yaml", c.AuthPolicyFile)
+}
+
+// TestEnvOverrides method
+func (suite *UnitSuite) TestEnvOverrides() {
+	os.Setenv("URLExpiry", "60")
+	c := &Config{DefaultsFilePath: defaultFileName}
+	err := c.Load()
+	suite.NoError(err)
+	suite.Equal(time.Hour, c.URLExpiry)
+}
+
+// TestEnvStationGroups method
+func (suite *UnitSuite) TestEnvStationGroups() {
+	os.Setenv("StationGroups", "{east: [station-1, station-2]}")
+	c := &Config{DefaultsFilePath: defaultFileName}
+	err := c.Load()
+	suite.NoError(err)
+	suite.Equal([]string{"station-1", "station-2"}, c.StationGroups["east"])
+}
+
+// TestEnvInvalidInt method
+func (suite *UnitSuite) TestEnvInvalidInt() {
+	os.Setenv("GraphqlMaxAttempts", "three")
+	c := &Config{DefaultsFilePath: defaultFileName}
+	err := c.Load()
+	suite.Error(err)
+}
+
+// TestLocalStorageSecret method
+func (suite *UnitSuite) TestLocalStorageSecret() {
+	os.Setenv("StorageBackend", "local")
+	c := &Config{DefaultsFilePath: defaultFileName}
+	suite.EqualError(c.Load(), "Missing LocalStorageSecret, required with StorageBackend local")
+
+	os.Setenv("LocalStorageSecret", "secret")
+	suite.NoError(c.Load())
+	suite.Equal("local", c.StorageBackend)
+}
+
+// TestUnitSuite function
+func TestUnitSuite(t *testing.T) {
+	suite.Run(t, new(UnitSuite))
+}
@@ -0,0 +1,158 @@
+package csv
+
+import (
+	"archive/zip"
+	"bytes"
+	enc "encoding/csv"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pulpfree/gdps-fs-dwnld/model"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// CSV struct holds a csv file for each section written, see OutputFile
+type CSV struct {
+	files []file
+	names map[string]bool // lower cased names of the files written so far
+}
+
+// file struct
+type file struct {
+	name string
+	rows [][]string
+}
+
+// ContentType of the generated zip file
+const ContentType = "application/zip"
+
+// Defaults
+const (
+	maxFileName   = 100
+	timeShortForm = "20060102"
+	timeMonthForm = "200601"
+	dateFrmt      = "2006-01-02"
+	monthFrmt     = "2006-01"
+)
+
+// NewFile function
+func NewFile() (c *CSV, err error) {
+	c = &CSV{
+		names: make(map[string]bool),
+	}
+	return c, err
+}
+
+// WriteSection method adds the section called name from sr as a new csv file,
+// named fileTitle or, if that's empty, the section title
+func (c *CSV) WriteSection(name, fileTitle string, sr *model.StationReport) (err error) {
+
+	s, err := model.SectionFor(name, sr)
+	if err != nil {
+		return err
+	}
+	rowsOf, ok := sectionRows[name]
+	if !ok {
+		return fmt.Errorf("No csv layout for report section %s", name)
+	}
+	if fileTitle == "" {
+		fileTitle = s.Title
+	}
+	rows, err := rowsOf(sr)
+	if err != nil {
+		return fmt.Errorf("%s for report section %s", err, name)
+	}
+
+	c.files = append(c.files, file{name: c.fileName(fileTitle), rows: rows})
+	return err
+}
+
+// OutputFile method zips the csv files in the order they were written
+func (c *CSV) OutputFile() (buf bytes.Buffer, err error) {
+
+	zw := zip.NewWriter(&buf)
+	for _, f := range c.files {
+		w, err := zw.Create(f.name)
+		if err != nil {
+			return buf, err
+		}
+		cw := enc.NewWriter(w)
+		if err = cw.WriteAll(f.rows); err != nil {
+			log.Errorf("csv err: %s", err)
+			return buf, err
+		}
+	}
+	if err = zw.Close(); err != nil {
+		log.Errorf("csv err: %s", err)
+	}
+	return buf, err
+}
+
+// OutputToDisk method
+func (c *CSV) OutputToDisk(path string) (fp string, err error) {
+	buf, err := c.OutputFile()
+	if err != nil {
+		return "", err
+	}
+	err = ioutil.WriteFile(path, buf.Bytes(), 0644)
+	return path, err
+}
+
+// ======================== Helper Methods ================================= //
+
+// fileName method strips characters that aren't safe in file names,
+// truncates to the maximum length and numbers any duplicates
+func (c *CSV) fileName(title string) string {
+
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`:\/?*[]"<>|`, r) || r < ' ' {
+			return -1
+		}
+		return r
+	}, title)
+	name = strings.Trim(name, " .")
+	if name == "" {
+		name = "Section"
+	}
+
+	base := truncate(name, maxFileName)
+	name = base
+	for n := 2; c.names[strings.ToLower(name)]; n++ {
+		sfx := fmt.Sprintf(" (%d)", n)
+		name = truncate(base, maxFileName-len(sfx)) + sfx
+	}
+	c.names[strings.ToLower(name)] = true
+
+	return name + ".csv"
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) > n {
+		return strings.TrimRight(string(r[:n]), " ")
+	}
+	return s
+}
+
+// formatDate function formats the YYYYMMDD dates used by the API
+func formatDate(date int64) string {
+	t, err := time.Parse(timeShortForm, strconv.FormatInt(date, 10))
+	if err != nil {
+		return strconv.FormatInt(date, 10)
+	}
+	return t.Format(dateFrmt)
+}
+
+// formatFloat function formats litres and amounts to 2 decimal places
+func formatFloat(num float64) string {
+	return strconv.FormatFloat(num, 'f', 2, 64)
+}
+
+// formatPrice function formats fuel prices per litre to 3 decimal places
+func formatPrice(num float64) string {
+	return strconv.FormatFloat(num, 'f', 3, 64)
+}
@@ -0,0 +1,86 @@
+package csv
+
+import (
+	"archive/zip"
+	"bytes"
+	enc "encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/pulpfree/gdps-fs-dwnld/fixture"
+	"github.com/pulpfree/gdps-fs-dwnld/model"
+	"github.com/stretchr/testify/suite"
+)
+
+const fixtureDir = "../fixture/testdata"
+
+// UnitSuite struct
+type UnitSuite struct {
+	suite.Suite
+	file *CSV
+	sr   *model.StationReport
+}
+
+// SetupTest method
+func (suite *UnitSuite) SetupTest() {
+	var err error
+	suite.file, err = NewFile()
+	suite.NoError(err)
+
+	suite.sr, err = fixture.StationReport(fixtureDir, &model.Request{Date: time.Date(2018, time.August, 1, 0, 0, 0, 0, time.UTC)})
+	suite.NoError(err)
+}
+
+// TestOutputFile method
+func (suite *UnitSuite) TestOutputFile() {
+	for _, name := range model.Sections {
+		suite.NoError(suite.file.WriteSection(name, "", suite.sr), name)
+	}
+	suite.NoError(suite.file.WriteSection(model.SectionFuelSales, "Station: 1/2", suite.sr))
+
+	files := suite.unzip()
+	suite.Len(files, len(model.Sections)+1)
+	suite.Contains(files, "Station 12.csv")
+
+	rows := files["Fuel Sales.csv"]
+	suite.Equal([]string{"Date", "NL", "SNL", "DSL", "CDSL"}, rows[0])
+	suite.Equal("2018-08-01", rows[1][0])
+	suite.Len(rows, len(suite.sr.FuelSales.Report.StationSales)+2)
+	suite.Equal("Total", rows[len(rows)-1][0])
+
+	rows = files["No-Lead Fuel Sales by Station.csv"]
+	suite.Equal("Price", rows[0][6])
+	suite.Len(rows, len(suite.sr.FuelSalesList.Report.PeriodSales)*len(suite.sr.FuelSalesList.Report.PeriodHeader)+1)
+
+	rows = files["Over-Short Annual.csv"]
+	suite.Equal("2018-01", rows[1][0])
+}
+
+// TestFileName method
+func (suite *UnitSuite) TestFileName() {
+	suite.Equal("Fuel Sales.csv", suite.file.fileName("Fuel Sales"))
+	suite.Equal("fuel sales (2).csv", suite.file.fileName("fuel sales"))
+	suite.Equal("Section.csv", suite.file.fileName("../"))
+}
+
+func (suite *UnitSuite) unzip() map[string][][]string {
+	buf, err := suite.file.OutputFile()
+	suite.NoError(err)
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	suite.NoError(err)
+
+	files := make(map[string][][]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		suite.NoError(err)
+		files[f.Name], err = enc.NewReader(rc).ReadAll()
+		suite.NoError(err)
+		rc.Close()
+	}
+	return files
+}
+
+// TestUnitSuite function
+func TestUnitSuite(t *testing.T) {
+	suite.Run(t, new(UnitSuite))
+}
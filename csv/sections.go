@@ -0,0 +1,172 @@
+package csv
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pulpfree/gdps-fs-dwnld/model"
+)
+
+// rowsFunc returns the csv rows of a section, sr holds the section's data
+type rowsFunc func(sr *model.StationReport) ([][]string, error)
+
+// sectionRows maps each section registered with model.RegisterSection to its rows
+var sectionRows = map[string]rowsFunc{
+	model.SectionFuelSales:       fuelSales,
+	model.SectionFuelSalesNL:     fuelSalesListNL,
+	model.SectionFuelSalesDSL:    fuelSalesListDSL,
+	model.SectionFuelDelivery:    fuelDelivery,
+	model.SectionOverShortMonth:  overShortMonth,
+	model.SectionOverShortAnnual: overShortAnnual,
+}
+
+// fuelSales function returns a row of sales by fuel type for each day, and a
+// totals row
+func fuelSales(sr *model.StationReport) (rows [][]string, err error) {
+
+	fs := sr.FuelSales
+	fuelTypes := fs.Report.FuelTypes
+
+	rows = append(rows, append([]string{"Date"}, fuelTypes...))
+	for _, r := range fs.Report.StationSales {
+		row := []string{formatDate(r.Date)}
+		for _, ft := range fuelTypes {
+			row = append(row, formatFloat(r.Sales[ft]))
+		}
+		rows = append(rows, row)
+	}
+
+	row := []string{"Total"}
+	for _, ft := range fuelTypes {
+		row = append(row, formatFloat(fs.Report.SalesSummary[ft]))
+	}
+	return append(rows, row), err
+}
+
+// fuelSalesListNL function returns a row for each station and week with its
+// no-lead sales and price
+func fuelSalesListNL(sr *model.StationReport) (rows [][]string, err error) {
+
+	fsl := sr.FuelSalesList
+
+	rows = append(rows, []string{"Station ID", "Station", "Week", "Start Date", "End Date", "NL", "Price"})
+	for _, sales := range fsl.Report.PeriodSales {
+		for i, ph := range fsl.Report.PeriodHeader {
+			rows = append(rows, []string{
+				sales.StationID,
+				sales.StationName,
+				ph.YearWeek,
+				ph.StartDate,
+				ph.EndDate,
+				formatFloat(sales.Periods[i].FuelSales["NL"]),
+				formatPrice(sales.FuelPrices.Prices[ph.YearWeek]),
+			})
+		}
+	}
+	return rows, err
+}
+
+// fuelSalesListDSL function returns a row for each station and week with its
+// diesel sales. Stations that sold no diesel have no rows.
+func fuelSalesListDSL(sr *model.StationReport) (rows [][]string, err error) {
+
+	fsl := sr.FuelSalesList
+
+	rows = append(rows, []string{"Station ID", "Station", "Week", "Start Date", "End Date", "DSL"})
+	for _, sales := range fsl.Report.PeriodSales {
+		if sales.StationTotal["DSL"] <= 0 {
+			continue
+		}
+		for i, ph := range fsl.Report.PeriodHeader {
+			rows = append(rows, []string{
+				sales.StationID,
+				sales.StationName,
+				ph.YearWeek,
+				ph.StartDate,
+				ph.EndDate,
+				formatFloat(sales.Periods[i].FuelSales["DSL"]),
+			})
+		}
+	}
+	return rows, err
+}
+
+// fuelDelivery function returns a row of litres delivered by fuel type for
+// each day, and a totals row
+func fuelDelivery(sr *model.StationReport) (rows [][]string, err error) {
+
+	fd := sr.FuelDelivery
+	fuelTypes := fd.Report.FuelTypes
+
+	rows = append(rows, append([]string{"Date"}, fuelTypes...))
+	for _, r := range fd.Report.Deliveries {
+		row := []string{formatDate(r.Date)}
+		for _, ft := range fuelTypes {
+			row = append(row, strconv.Itoa(int(r.Data[ft])))
+		}
+		rows = append(rows, row)
+	}
+
+	row := []string{"Total"}
+	for _, ft := range fuelTypes {
+		row = append(row, formatFloat(fd.Report.DeliverySummary[ft]))
+	}
+	return append(rows, row), err
+}
+
+// overShortMonth function returns a row of over-short by fuel type for each
+// day, and a totals row
+func overShortMonth(sr *model.StationReport) (rows [][]string, err error) {
+
+	os := sr.OverShortMonth
+	fuelTypes := os.Report.FuelTypes
+
+	rows = append(rows, append([]string{"Date"}, fuelTypes...))
+	for _, r := range os.Report.OverShort {
+		row := []string{formatDate(r.Date)}
+		for _, ft := range fuelTypes {
+			row = append(row, formatFloat(r.Data[ft].OverShort))
+		}
+		rows = append(rows, row)
+	}
+
+	row := []string{"Total"}
+	for _, ft := range fuelTypes {
+		row = append(row, formatFloat(os.Report.OverShortSummary[ft]))
+	}
+	return append(rows, row), err
+}
+
+// overShortAnnual function returns a row of over-short by fuel type for each
+// month, and a totals row
+func overShortAnnual(sr *model.StationReport) (rows [][]string, err error) {
+
+	os := sr.OverShortAnnual
+	fuelTypes := os.Report.FuelTypes
+
+	var months []string
+	for m := range os.Report.Months {
+		months = append(months, m)
+	}
+	sort.Strings(months)
+
+	rows = append(rows, append([]string{"Month"}, fuelTypes...))
+	for _, m := range months {
+		label := m
+		if t, err := time.Parse(timeMonthForm, m); err == nil {
+			label = t.Format(monthFrmt)
+		}
+		row := []string{label}
+		for _, ft := range fuelTypes {
+			row = append(row, formatFloat(os.Report.Months[m][ft]))
+		}
+		rows = append(rows, row)
+	}
+
+	row := []string{"Total"}
+	for _, ft := range fuelTypes {
+		row = append(row, formatFloat(os.Report.Summary[ft]))
+	}
+	return append(rows, row), err
+}
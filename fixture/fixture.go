@@ -0,0 +1,141 @@
+package fixture
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"path"
+
+	"github.com/pulpfree/gdps-fs-dwnld/model"
+)
+
+// Fixture file names
+const (
+	FuelSalesFile       = "fuelSaleMonth.json"
+	FuelSalesListFile   = "fuelSaleListReport.json"
+	FuelDeliveryFile    = "fuelDeliveryReport.json"
+	OverShortMonthFile  = "dipOSMonthReport.json"
+	OverShortAnnualFile = "dipOSAnnualReport.json"
+)
+
+// Files lists every fixture file a Source loads
+var Files = []string{
+	FuelSalesFile,
+	FuelSalesListFile,
+	FuelDeliveryFile,
+	OverShortMonthFile,
+	OverShortAnnualFile,
+}
+
+// Source struct is an in-memory report source. Each fixture file holds the data
+// portion of a GraphQL response and is named after the query field it answers.
+type Source struct {
+	data    map[string][]byte
+	request *model.Request
+}
+
+// Load function reads all fixture files in dir into a new Source
+func Load(dir string, req *model.Request) (s *Source, err error) {
+
+	s = &Source{
+		data:    make(map[string][]byte, len(Files)),
+		request: req,
+	}
+	for _, fileNm := range Files {
+		s.data[fileNm], err = ioutil.ReadFile(path.Join(dir, fileNm))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s, err
+}
+
+// StationReport function loads every section of the fixtures in dir for req
+func StationReport(dir string, req *model.Request) (sr *model.StationReport, err error) {
+
+	s, err := Load(dir, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	sr = new(model.StationReport)
+	if sr.FuelSales, err = s.FuelSales(ctx); err != nil {
+		return nil, err
+	}
+	if sr.FuelSalesList, err = s.FuelSalesList(ctx); err != nil {
+		return nil, err
+	}
+	if sr.FuelDelivery, err = s.FuelDelivery(ctx); err != nil {
+		return nil, err
+	}
+	if sr.OverShortMonth, err = s.OverShortMonth(ctx); err != nil {
+		return nil, err
+	}
+	if sr.OverShortAnnual, err = s.OverShortAnnual(ctx); err != nil {
+		return nil, err
+	}
+	return sr, err
+}
+
+// FuelSales method
+func (s *Source) FuelSales(ctx context.Context) (rpt *model.FuelSales, err error) {
+	rpt = new(model.FuelSales)
+	if err = s.load(ctx, FuelSalesFile, rpt); err != nil {
+		return nil, err
+	}
+	rpt.Date = s.request.Date
+	rpt.Report.FuelTypes = model.SortFuelTypes(rpt.Report.FuelTypes)
+	return rpt, err
+}
+
+// FuelSalesList method
+func (s *Source) FuelSalesList(ctx context.Context) (rpt *model.FuelSalesList, err error) {
+	rpt = new(model.FuelSalesList)
+	if err = s.load(ctx, FuelSalesListFile, rpt); err != nil {
+		return nil, err
+	}
+	rpt.Date = s.request.Date
+	return rpt, err
+}
+
+// FuelDelivery method
+func (s *Source) FuelDelivery(ctx context.Context) (rpt *model.FuelDelivery, err error) {
+	rpt = new(model.FuelDelivery)
+	if err = s.load(ctx, FuelDeliveryFile, rpt); err != nil {
+		return nil, err
+	}
+	rpt.Date = s.request.Date
+	rpt.Report.FuelTypes = model.SortFuelTypes(rpt.Report.FuelTypes)
+	return rpt, err
+}
+
+// OverShortMonth method
+func (s *Source) OverShortMonth(ctx context.Context) (rpt *model.OverShortMonth, err error) {
+	rpt = new(model.OverShortMonth)
+	if err = s.load(ctx, OverShortMonthFile, rpt); err != nil {
+		return nil, err
+	}
+	rpt.Date = s.request.Date
+	rpt.Report.FuelTypes = model.SortFuelTypes(rpt.Report.FuelTypes)
+	return rpt, err
+}
+
+// OverShortAnnual method
+func (s *Source) OverShortAnnual(ctx context.Context) (rpt *model.OverShortAnnual, err error) {
+	rpt = new(model.OverShortAnnual)
+	if err = s.load(ctx, OverShortAnnualFile, rpt); err != nil {
+		return nil, err
+	}
+	rpt.Date = s.request.Date
+	rpt.Report.FuelTypes = model.SortFuelTypes(rpt.Report.FuelTypes)
+	return rpt, err
+}
+
+// load method decodes a fixture file into v
+func (s *Source) load(ctx context.Context, fileNm string, v interface{}) (err error) {
+	if err = ctx.Err(); err != nil {
+		return err
+	}
+	return json.Unmarshal(s.data[fileNm], v)
+}
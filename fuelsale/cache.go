@@ -0,0 +1,81 @@
+package fuelsale
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// ReportVersion must be bumped whenever the workbook layout changes so that
+// cached reports built by an earlier version are no longer reused
+const ReportVersion = "3"
+
+// CachedURL method returns a signed url for a previously stored copy of the
+// report when caching is enabled, the request doesn't force a rebuild and the
+// month is closed. ok is false when the report needs to be created.
+func (r *Report) CachedURL(ctx context.Context) (url string, ok bool, err error) {
+
+	if r.store == nil || r.cfg == nil || !r.cfg.ReportCache || r.request.Force {
+		return "", false, nil
+	}
+	if !monthClosed(r.period().end, time.Now(), r.cfg.CacheFreezeDays) {
+		return "", false, nil
+	}
+
+	key := r.cacheKey()
+	exists, err := r.store.Exists(ctx, key)
+	if err != nil {
+		// A cache failure shouldn't prevent creating the report
+		log.Errorf("Error checking report cache for %s: %s", key, err)
+		return "", false, nil
+	}
+	if !exists {
+		return "", false, nil
+	}
+
+	log.Infof("Using cached report %s", key)
+	url, err = r.store.SignedURL(ctx, key, r.urlExpiry())
+	if err != nil {
+		return "", false, err
+	}
+	return url, true, err
+}
+
+// cacheKey method builds the storage key for the report from the station,
+// period, report version, the sections it contains, whether it has charts and
+// the output format
+func (r *Report) cacheKey() string {
+
+	// Sections are hashed in order as it decides the order of the sheets
+	contents := strings.Join(r.sections, ",")
+	if r.request.NoCharts {
+		contents += ",nocharts"
+	}
+	sum := sha256.Sum256([]byte(contents))
+
+	station := r.request.StationID
+	if r.isGroup() {
+		station = r.groupKey()
+	}
+
+	return fmt.Sprintf("%s/%s/%s_v%s_%s.%s",
+		station,
+		r.periodName(),
+		reportFileName,
+		ReportVersion,
+		hex.EncodeToString(sum[:])[:12],
+		r.format.ext,
+	)
+}
+
+// monthClosed function reports whether the month containing date ended more
+// than freezeDays before now
+func monthClosed(date, now time.Time, freezeDays int) bool {
+	nextMonth := time.Date(date.Year(), date.Month()+1, 1, 0, 0, 0, 0, date.Location())
+	return now.After(nextMonth.AddDate(0, 0, freezeDays))
+}
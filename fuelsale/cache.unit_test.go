@@ -0,0 +1,137 @@
+package fuelsale
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/pulpfree/gdps-fs-dwnld/config"
+	"github.com/pulpfree/gdps-fs-dwnld/fixture"
+	"github.com/pulpfree/gdps-fs-dwnld/model"
+	"github.com/pulpfree/gdps-fs-dwnld/storage"
+	"github.com/stretchr/testify/suite"
+)
+
+// CacheSuite struct
+type CacheSuite struct {
+	suite.Suite
+	dir    string
+	cfg    *config.Config
+	store  storage.Storage
+	report *Report
+}
+
+// SetupTest method
+func (suite *CacheSuite) SetupTest() {
+	var err error
+	suite.dir, err = ioutil.TempDir("", "cache")
+	suite.NoError(err)
+	suite.store, err = storage.NewLocal(suite.dir, "http://localhost/files", "test-secret")
+	suite.NoError(err)
+
+	suite.cfg = &config.Config{}
+	suite.cfg.ReportCache = true
+	suite.cfg.CacheFreezeDays = 30
+	suite.report = suite.newReport(false)
+}
+
+// TearDownTest method
+func (suite *CacheSuite) TearDownTest() {
+	os.RemoveAll(suite.dir)
+}
+
+// TestCachedURL method
+func (suite *CacheSuite) TestCachedURL() {
+	ctx := context.Background()
+
+	_, ok, err := suite.report.CachedURL(ctx)
+	suite.NoError(err)
+	suite.False(ok, "Expected cache miss before report is stored")
+
+	suite.NoError(suite.report.Create(ctx))
+	key, err := suite.report.Save(ctx)
+	suite.NoError(err)
+	suite.Equal(suite.report.cacheKey(), key)
+
+	url, ok, err := suite.newReport(false).CachedURL(ctx)
+	suite.NoError(err)
+	suite.True(ok, "Expected cache hit")
+	suite.Contains(url, stationID+"/2018-08/")
+
+	_, ok, err = suite.newReport(true).CachedURL(ctx)
+	suite.NoError(err)
+	suite.False(ok, "Expected force to skip the cache")
+
+	suite.cfg.ReportCache = false
+	_, ok, err = suite.newReport(false).CachedURL(ctx)
+	suite.NoError(err)
+	suite.False(ok, "Expected disabled cache to be skipped")
+}
+
+// TestCacheKey method
+func (suite *CacheSuite) TestCacheKey() {
+	key := suite.report.cacheKey()
+	suite.Equal(key, suite.newReport(false).cacheKey())
+
+	suite.report.sections = []string{model.SectionFuelDelivery}
+	suite.NotEqual(key, suite.report.cacheKey())
+
+	r := suite.newReport(false)
+	r.format = outputFormats[model.FormatCSV]
+	suite.NotEqual(key, r.cacheKey())
+	suite.Contains(r.cacheKey(), ".zip")
+
+	r.format = outputFormats[model.FormatPDF]
+	suite.Contains(r.cacheKey(), ".pdf")
+
+	r.format = outputFormats[model.FormatJSON]
+	suite.Contains(r.cacheKey(), ".json")
+
+	r = suite.newReport(false)
+	r.request.NoCharts = true
+	suite.NotEqual(key, r.cacheKey())
+}
+
+// TestGroupCacheKey method
+func (suite *CacheSuite) TestGroupCacheKey() {
+	newGroup := func(name string, ids ...string) *Report {
+		req := &model.Request{Date: suite.report.request.Date, StationIDs: ids, StationGroup: name}
+		r, err := NewFromSourceFunc(req, suite.cfg, func(*model.Request) ReportSource { return nil }, suite.store)
+		suite.NoError(err)
+		return r
+	}
+
+	key := newGroup("east", "a", "b").cacheKey()
+	suite.Equal(key, newGroup("east", "a", "b").cacheKey())
+	suite.NotEqual(key, newGroup("east", "b", "a").cacheKey(), "Expected the tab order in the key")
+	suite.NotEqual(key, newGroup("west", "a", "b").cacheKey(), "Expected the group name in the key")
+	suite.NotEqual(key, newGroup("", "a", "b").cacheKey())
+	suite.NotEqual(key, newGroup("east", "a", "c").cacheKey())
+	suite.Contains(key, "group-")
+	suite.NotEqual(key, suite.report.cacheKey())
+}
+
+// TestMonthClosed method
+func (suite *CacheSuite) TestMonthClosed() {
+	aug := time.Date(2018, time.August, 1, 0, 0, 0, 0, time.UTC)
+	suite.False(monthClosed(aug, time.Date(2018, time.August, 20, 0, 0, 0, 0, time.UTC), 0))
+	suite.False(monthClosed(aug, time.Date(2018, time.September, 20, 0, 0, 0, 0, time.UTC), 30))
+	suite.True(monthClosed(aug, time.Date(2018, time.October, 2, 0, 0, 0, 0, time.UTC), 30))
+}
+
+func (suite *CacheSuite) newReport(force bool) *Report {
+	dte, _ := time.Parse(timeFormat, date)
+	req := &model.Request{Date: dte, StationID: stationID, Force: force}
+	src, err := fixture.Load(fixtureDir, req)
+	suite.NoError(err)
+	r, err := New(req, suite.cfg, src, suite.store)
+	suite.NoError(err)
+	return r
+}
+
+// TestCacheSuite function
+func TestCacheSuite(t *testing.T) {
+	suite.Run(t, new(CacheSuite))
+}
@@ -0,0 +1,176 @@
+package fuelsale
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pulpfree/gdps-fs-dwnld/model"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const defaultFetchConcurrency = 3
+
+// query struct describes a ReportSource query and the section data it provides
+type query struct {
+	name   string
+	data   string // see model.Section Data
+	shared bool   // the same for every station, so only fetched for the first
+	yearly bool   // covers the year to date, so only fetched for the last month of each year
+	fetch  func(ctx context.Context, src ReportSource, sr *model.StationReport) error
+}
+
+// queries lists every ReportSource query in fetch order
+var queries = []query{
+	{name: "FuelSales", data: model.DataFuelSales,
+		fetch: func(ctx context.Context, src ReportSource, sr *model.StationReport) (err error) {
+			sr.FuelSales, err = src.FuelSales(ctx)
+			return err
+		}},
+	{name: "FuelSalesList", data: model.DataFuelSalesList, shared: true,
+		fetch: func(ctx context.Context, src ReportSource, sr *model.StationReport) (err error) {
+			sr.FuelSalesList, err = src.FuelSalesList(ctx)
+			return err
+		}},
+	{name: "FuelDelivery", data: model.DataFuelDelivery,
+		fetch: func(ctx context.Context, src ReportSource, sr *model.StationReport) (err error) {
+			sr.FuelDelivery, err = src.FuelDelivery(ctx)
+			return err
+		}},
+	{name: "OverShortMonth", data: model.DataOverShortMonth,
+		fetch: func(ctx context.Context, src ReportSource, sr *model.StationReport) (err error) {
+			sr.OverShortMonth, err = src.OverShortMonth(ctx)
+			return err
+		}},
+	{name: "OverShortAnnual", data: model.DataOverShortAnnual, yearly: true,
+		fetch: func(ctx context.Context, src ReportSource, sr *model.StationReport) (err error) {
+			sr.OverShortAnnual, err = src.OverShortAnnual(ctx)
+			return err
+		}},
+}
+
+// queryFor function returns the query providing data
+func queryFor(data string) (q query, ok bool) {
+	for _, q = range queries {
+		if q.data == data {
+			return q, true
+		}
+	}
+	return q, false
+}
+
+// fetchTask struct
+type fetchTask struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// fetchAll fetches the data the requested sections depend on. With GraphqlBatch
+// set and a source that supports it this is a single combined query, otherwise
+// each report query runs concurrently, limited to the configured concurrency.
+// The first failure cancels the remaining queries.
+func (r *Report) fetchAll(ctx context.Context) (sr *model.StationReport, err error) {
+
+	src := r.source
+	if client, ok := src.(StationReporter); ok && r.cfg != nil && r.cfg.GraphqlBatch {
+		return fetchBatch(ctx, client)
+	}
+
+	sr = new(model.StationReport)
+	need := r.dataNeeded()
+	var tasks []fetchTask
+	for _, q := range queries {
+		if need[q.data] {
+			tasks = append(tasks, queryTask(q, q.name, src, sr))
+		}
+	}
+
+	if err = runTasks(ctx, r.fetchConcurrency(), tasks); err != nil {
+		return nil, err
+	}
+	return sr, err
+}
+
+// fetchBatch function fetches all sections with the combined StationReport query
+func fetchBatch(ctx context.Context, client StationReporter) (sr *model.StationReport, err error) {
+
+	sr, err = client.StationReport(ctx)
+	if err != nil {
+		log.Errorf("Error fetching StationReport: %s", err)
+		return nil, err
+	}
+	return sr, err
+}
+
+// queryTask function returns a task running q against src and storing the result in sr
+func queryTask(q query, name string, src ReportSource, sr *model.StationReport) fetchTask {
+	return fetchTask{name, func(ctx context.Context) error {
+		return q.fetch(ctx, src, sr)
+	}}
+}
+
+// dataNeeded method returns the data the requested sections are rendered from
+func (r *Report) dataNeeded() map[string]bool {
+	need := make(map[string]bool)
+	for _, name := range r.sections {
+		if s, ok := model.LookupSection(name); ok {
+			need[s.Data] = true
+		}
+	}
+	return need
+}
+
+// fetchConcurrency method
+func (r *Report) fetchConcurrency() int {
+	if r.cfg == nil || r.cfg.FetchConcurrency < 1 {
+		return defaultFetchConcurrency
+	}
+	return r.cfg.FetchConcurrency
+}
+
+// runTasks executes tasks with at most limit running at once and returns the
+// first error encountered. Tasks waiting on a slot are skipped once any task fails.
+func runTasks(ctx context.Context, limit int, tasks []fetchTask) (err error) {
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg   sync.WaitGroup
+		once sync.Once
+	)
+	sem := make(chan struct{}, limit)
+
+	for _, t := range tasks {
+		select {
+		case <-ctx.Done():
+		case sem <- struct{}{}:
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(t fetchTask) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if ctx.Err() != nil {
+				return
+			}
+			if tErr := t.fn(ctx); tErr != nil {
+				log.Errorf("Error fetching %s: %s", t.name, tErr)
+				once.Do(func() {
+					err = tErr
+					cancel()
+				})
+			}
+		}(t)
+	}
+	wg.Wait()
+
+	if err == nil && ctx.Err() != nil {
+		err = ctx.Err()
+	}
+	return err
+}
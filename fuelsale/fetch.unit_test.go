@@ -0,0 +1,60 @@
+package fuelsale
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// FetchSuite struct
+type FetchSuite struct {
+	suite.Suite
+}
+
+// TestRunTasksLimit method
+func (suite *FetchSuite) TestRunTasksLimit() {
+	var running, maxRunning int32
+	task := func(ctx context.Context) error {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return nil
+	}
+
+	tasks := make([]fetchTask, 6)
+	for i := range tasks {
+		tasks[i] = fetchTask{name: "task", fn: task}
+	}
+	err := runTasks(context.Background(), 2, tasks)
+	suite.NoError(err)
+	suite.True(maxRunning <= 2, "Expected no more than 2 concurrent tasks")
+}
+
+// TestRunTasksCancel method
+func (suite *FetchSuite) TestRunTasksCancel() {
+	var called int32
+	fail := errors.New("fetch failed")
+	tasks := []fetchTask{
+		{"first", func(ctx context.Context) error { return fail }},
+		{"second", func(ctx context.Context) error { atomic.AddInt32(&called, 1); return nil }},
+		{"third", func(ctx context.Context) error { atomic.AddInt32(&called, 1); return nil }},
+	}
+	err := runTasks(context.Background(), 1, tasks)
+	suite.Equal(fail, err)
+	suite.Equal(int32(0), called, "Expected remaining tasks to be skipped")
+}
+
+// TestFetchSuite function
+func TestFetchSuite(t *testing.T) {
+	suite.Run(t, new(FetchSuite))
+}
@@ -1,119 +1,129 @@
 package fuelsale
 
 import (
+	"context"
+	"errors"
 	"path"
+	"time"
 
-	"github.com/pulpfree/gdps-fs-dwnld/awsservices"
 	"github.com/pulpfree/gdps-fs-dwnld/config"
-	"github.com/pulpfree/gdps-fs-dwnld/graphql"
 	"github.com/pulpfree/gdps-fs-dwnld/model"
-	"github.com/pulpfree/gdps-fs-dwnld/xlsx"
+	"github.com/pulpfree/gdps-fs-dwnld/storage"
 
 	log "github.com/sirupsen/logrus"
 )
 
 // ReportName constant
 const (
-	reportFileName = "StationReport"
-	timeFrmt       = "2006-01"
+	defaultURLExpiry = 15 * time.Minute
+	reportFileName   = "StationReport"
+	timeFrmt         = "2006-01"
+	dateFrmt         = "2006-01-02"
 )
 
+// ReportSource interface provides the data for each report section
+type ReportSource interface {
+	FuelSales(ctx context.Context) (*model.FuelSales, error)
+	FuelSalesList(ctx context.Context) (*model.FuelSalesList, error)
+	FuelDelivery(ctx context.Context) (*model.FuelDelivery, error)
+	OverShortMonth(ctx context.Context) (*model.OverShortMonth, error)
+	OverShortAnnual(ctx context.Context) (*model.OverShortAnnual, error)
+}
+
+// StationReporter interface is implemented by sources able to fetch
+// every section at once, see graphql.Client.StationReport
+type StationReporter interface {
+	StationReport(ctx context.Context) (*model.StationReport, error)
+}
+
 // Report struct
 type Report struct {
-	authToken string
 	cfg       *config.Config
 	request   *model.Request
-	file      *xlsx.XLSX
+	source    ReportSource
+	newSource SourceFunc // set for group reports
+	store     storage.Storage
+	file      Document
+	format    outputFormat
 	filenm    string
+	sections  []string
+	progress  func(section string) // optional, see SetProgress
 }
 
 // New function
-func New(req *model.Request, cfg *config.Config, authToken string) (r *Report, err error) {
+func New(req *model.Request, cfg *config.Config, src ReportSource, store storage.Storage) (r *Report, err error) {
+	if src == nil {
+		return nil, errors.New("Missing ReportSource")
+	}
+	if req.IsRange() || len(req.StationIDs) > 0 {
+		return nil, errors.New("Date range and multi-station reports need a source for each station and month, see NewFromSourceFunc")
+	}
 	r = &Report{
-		authToken: authToken,
-		cfg:       cfg,
-		request:   req,
+		cfg:      cfg,
+		request:  req,
+		source:   src,
+		store:    store,
+		sections: req.SectionList(),
+	}
+	r.format, err = r.output()
+	if err != nil {
+		return nil, err
 	}
 	return r, err
 }
 
 // Create method
-func (r *Report) Create() (err error) {
+func (r *Report) Create(ctx context.Context) (err error) {
 
-	// Init graphql and xlsx packages
-	client := graphql.New(r.request, r.cfg, r.authToken)
-	r.file, err = xlsx.NewFile()
+	r.file, err = r.format.newDocument(r.request)
 	if err != nil {
 		return err
 	}
 
-	// Fetch and create Fuel Sales
-	fs, err := client.FuelSales()
-	if err != nil {
-		log.Errorf("Error fetching FuelSales: %s", err)
-		return err
+	// Fetch all report sections concurrently
+	var sr *model.StationReport
+	if r.newSource != nil {
+		srs, err := r.fetchStations(ctx)
+		if err != nil {
+			return err
+		}
+		if r.isGroup() {
+			return r.writeGroup(srs)
+		}
+		sr = srs[0]
+	} else {
+		sr, err = r.fetchAll(ctx)
 	}
-	// Now that we have the station name, we can set
-	// would be nice to do earlier, but requires separate query
-	r.setFileName(fs.Station.Name)
-
-	err = r.file.FuelSales(fs)
 	if err != nil {
 		return err
 	}
 
-	// Fetch and create NL and DSL Fuel Sales by Station
-	fsl, err := client.FuelSalesList()
-	if err != nil {
-		log.Errorf("Error fetching FuelSalesList: %s", err)
-		return err
-	}
-	err = r.file.FuelSalesListNL(fsl)
-	if err != nil {
-		log.Errorf("Error creating FuelSalesListNL: %s", err)
-		return err
-	}
-
-	err = r.file.FuelSalesListDSL(fsl)
-	if err != nil {
-		log.Errorf("Error creating FuelSalesListDSL: %s", err)
-		return err
+	// Now that we have the station name, we can set
+	r.setFileName(r.stationName(sr))
+
+	// Sections are written in the requested order once all data has arrived
+	for _, name := range r.sections {
+		if err = r.file.WriteSection(name, "", sr); err != nil {
+			log.Errorf("Error creating %s: %s", name, err)
+			return err
+		}
+		r.sectionDone(name)
 	}
 
-	// Fetch and create Fuel Delivery
-	fd, err := client.FuelDelivery()
-	if err != nil {
-		log.Errorf("Error fetching FuelDelivery: %s", err)
-		return err
-	}
-	err = r.file.FuelDelivery(fd)
-	if err != nil {
-		return err
-	}
+	return err
+}
 
-	// Fetch and create monthly overshort
-	osm, err := client.OverShortMonth()
-	if err != nil {
-		log.Errorf("Error fetching FuelSales: %s", err)
-		return err
-	}
-	err = r.file.OverShortMonth(osm)
-	if err != nil {
-		return err
-	}
+// SetProgress method sets a function Create calls with the name of each
+// section once it is written
+func (r *Report) SetProgress(fn func(section string)) {
+	r.progress = fn
+}
 
-	// Fetch and create annual overshort
-	osa, err := client.OverShortAnnual()
-	if err != nil {
-		log.Errorf("Error fetching FuelSales: %s", err)
-		return err
-	}
-	err = r.file.OverShortAnnual(osa)
-	if err != nil {
-		return err
+// sectionDone method reports progress on the section
+func (r *Report) sectionDone(name string) {
+	if r.progress != nil {
+		r.progress(name)
 	}
-
-	return err
 }
 
 // SaveToDisk method
@@ -127,26 +137,94 @@ func (r *Report) SaveToDisk(dir string) (fp string, err error) {
 	return fp, err
 }
 
-// CreateSignedURL method
-func (r *Report) CreateSignedURL() (url string, err error) {
+// CreateSignedURL method stores the file and returns a signed link to it
+func (r *Report) CreateSignedURL(ctx context.Context) (url string, err error) {
+
+	key, err := r.Save(ctx)
+	if err != nil {
+		return "", err
+	}
+	return r.store.SignedURL(ctx, key, r.urlExpiry())
+}
+
+// Save method stores the file under its cache key and returns the key. The
+// download keeps the readable file name.
+func (r *Report) Save(ctx context.Context) (key string, err error) {
+
+	if r.store == nil {
+		return "", errors.New("Missing report storage")
+	}
 
 	output, err := r.file.OutputFile()
 	if err != nil {
 		return "", err
 	}
 
-	s3Serv, err := awsservices.NewS3(r.cfg)
-	return s3Serv.GetSignedURL(r.getFileName(), &output)
+	key = r.cacheKey()
+	err = r.store.Put(ctx, key, &output, storage.PutOptions{
+		ContentType:        r.format.contentType,
+		ContentDisposition: storage.AttachmentDisposition(r.getFileName()),
+	})
+	if err != nil {
+		log.Errorf("Failed to store file: %s", err)
+		return "", err
+	}
+	return key, err
 }
 
 //
 // ======================== Helper Functions =============================== //
 //
 
+// stationName method finds the station name in whichever sections were
+// fetched. The station list names every station so is checked last.
+func (r *Report) stationName(sr *model.StationReport) string {
+	switch {
+	case sr.FuelSales != nil:
+		return sr.FuelSales.Station.Name
+	case sr.FuelDelivery != nil:
+		return sr.FuelDelivery.Station.Name
+	case sr.OverShortMonth != nil:
+		return sr.OverShortMonth.Station.Name
+	case sr.OverShortAnnual != nil:
+		return sr.OverShortAnnual.Station.Name
+	case sr.FuelSalesList != nil:
+		for _, ps := range sr.FuelSalesList.Report.PeriodSales {
+			if ps.StationID == r.request.StationID {
+				return ps.StationName
+			}
+		}
+	}
+	return r.request.StationID
+}
+
 func (r *Report) setFileName(stationName string) {
-	r.filenm = stationName + "_" + reportFileName + "_" + r.request.Date.Format(timeFrmt) + ".xlsx"
+	r.filenm = stationName + "_" + reportFileName + "_" + r.periodName() + "." + r.format.ext
 }
 
 func (r *Report) getFileName() string {
 	return r.filenm
 }
+
+// urlExpiry method returns the signed url lifetime for the report, see URLExpiry
+func (r *Report) urlExpiry() time.Duration {
+	return URLExpiry(r.request.URLExpiry, r.cfg)
+}
+
+// URLExpiry function returns the requested signed url lifetime, falling back
+// to the configured default and capped at the configured maximum
+func URLExpiry(requested time.Duration, cfg *config.Config) time.Duration {
+
+	expiry := requested
+	if expiry <= 0 && cfg != nil {
+		expiry = cfg.URLExpiry
+	}
+	if expiry <= 0 {
+		expiry = defaultURLExpiry
+	}
+	if cfg != nil && cfg.URLExpiryMax > 0 && expiry > cfg.URLExpiryMax {
+		log.Infof("Requested url expiry %s exceeds maximum, using %s", expiry, cfg.URLExpiryMax)
+		expiry = cfg.URLExpiryMax
+	}
+	return expiry
+}
@@ -1,19 +1,26 @@
 package fuelsale
 
 import (
+	"context"
+	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/pulpfree/gdps-fs-dwnld/config"
+	"github.com/pulpfree/gdps-fs-dwnld/gdpstest"
+	"github.com/pulpfree/gdps-fs-dwnld/graphql"
 	"github.com/pulpfree/gdps-fs-dwnld/model"
+	"github.com/pulpfree/gdps-fs-dwnld/storage"
 	"github.com/stretchr/testify/suite"
 )
 
 const (
 	date             = "2018-08-01"
 	defaultsFilePath = "../config/defaults.yaml"
+	fixtureDir       = "../fixture/testdata"
 	// stationID        = "449d51e8-23ab-4102-8385-57eb9f31f22f"
 	stationID  = "d03224a7-f1df-4863-bcaa-5c6e61af11fc"
 	timeFormat = "2006-01-02"
@@ -25,6 +32,9 @@ type UnitSuite struct {
 	c       *config.Config
 	request *model.Request
 	report  *Report
+	server  *gdpstest.Server
+	files   *httptest.Server
+	tmpDir  string
 }
 
 // SetupTest method
@@ -40,14 +50,38 @@ func (suite *UnitSuite) SetupTest() {
 	suite.NoError(err)
 	suite.IsType(new(config.Config), suite.c)
 
-	suite.report, err = New(suite.request, suite.c, "")
+	suite.server, err = gdpstest.NewServer(fixtureDir)
+	suite.NoError(err)
+	suite.c.GraphqlURI = suite.server.URL
+	suite.tmpDir, err = ioutil.TempDir("", "fuelsale")
+	suite.NoError(err)
+
+	// Serve stored reports locally in place of S3
+	mux := http.NewServeMux()
+	suite.files = httptest.NewServer(mux)
+	suite.c.StorageBackend = storage.BackendLocal
+	suite.c.LocalStorageDir = suite.tmpDir
+	suite.c.LocalStorageURL = suite.files.URL + "/files"
+	suite.c.LocalStorageSecret = "test-secret"
+	store, err := storage.New(suite.c)
+	suite.NoError(err)
+	mux.Handle("/files/", store.(*storage.Local).Handler())
+
+	suite.report, err = New(suite.request, suite.c, graphql.New(suite.request, suite.c, ""), store)
 	suite.NoError(err)
 	suite.IsType(new(Report), suite.report)
 
-	err = suite.report.Create()
+	err = suite.report.Create(context.Background())
 	suite.NoError(err)
 }
 
+// TearDownTest method
+func (suite *UnitSuite) TearDownTest() {
+	suite.server.Close()
+	suite.files.Close()
+	os.RemoveAll(suite.tmpDir)
+}
+
 // TestConfig method
 func (suite *UnitSuite) TestConfig() {
 	suite.NotEqual("", suite.c.AWSRegion, "Expected AWSRegion to be populated")
@@ -55,20 +89,20 @@ func (suite *UnitSuite) TestConfig() {
 
 // TestSaveToDisk method
 func (suite *UnitSuite) TestSaveToDisk() {
-	err := suite.report.Create()
+	err := suite.report.Create(context.Background())
 	suite.NoError(err)
 
-	fp, err := suite.report.SaveToDisk("../tmp")
+	fp, err := suite.report.SaveToDisk(suite.tmpDir)
 	suite.NoError(err)
 	suite.NotEqual("", fp, "Expected file path to be populated")
 }
 
 // TestCreateSignedURL method
 func (suite *UnitSuite) TestCreateSignedURL() {
-	err := suite.report.Create()
+	err := suite.report.Create(context.Background())
 	suite.NoError(err)
 
-	url, err := suite.report.CreateSignedURL()
+	url, err := suite.report.CreateSignedURL(context.Background())
 	suite.NoError(err)
 	suite.NotEqual("", url, "Expected url to be populated")
 
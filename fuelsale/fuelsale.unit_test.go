@@ -0,0 +1,391 @@
+package fuelsale
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/360EntSecGroup-Skylar/excelize"
+	"github.com/pulpfree/gdps-fs-dwnld/config"
+	"github.com/pulpfree/gdps-fs-dwnld/fixture"
+	"github.com/pulpfree/gdps-fs-dwnld/model"
+	"github.com/stretchr/testify/suite"
+)
+
+var _ ReportSource = (*fixture.Source)(nil)
+
+// FixtureSuite struct
+type FixtureSuite struct {
+	suite.Suite
+	report *Report
+}
+
+// SetupTest method
+func (suite *FixtureSuite) SetupTest() {
+	dte, err := time.Parse(timeFormat, date)
+	suite.NoError(err)
+	req := &model.Request{
+		Date:      dte,
+		StationID: stationID,
+	}
+
+	src, err := fixture.Load(fixtureDir, req)
+	suite.NoError(err)
+
+	suite.report, err = New(req, &config.Config{}, src, nil)
+	suite.NoError(err)
+}
+
+// TestCreate method
+func (suite *FixtureSuite) TestCreate() {
+	var written []string
+	suite.report.SetProgress(func(section string) { written = append(written, section) })
+	err := suite.report.Create(context.Background())
+	suite.NoError(err)
+	suite.Equal(model.Sections, written)
+	suite.Equal("Test Station_StationReport_2018-08.xlsx", suite.report.getFileName())
+
+	dir, err := ioutil.TempDir("", "fuelsale")
+	suite.NoError(err)
+	defer os.RemoveAll(dir)
+
+	fp, err := suite.report.SaveToDisk(dir)
+	suite.NoError(err)
+	suite.Equal(path.Join(dir, suite.report.getFileName()), fp)
+	suite.FileExists(fp)
+}
+
+// TestCreateGroup method
+func (suite *FixtureSuite) TestCreateGroup() {
+	req := *suite.report.request
+	req.StationID = ""
+	req.StationIDs = []string{stationID, "449d51e8-7e30-4ea2-8ba0-4f6bd3fbbf1e"}
+	req.StationGroup = "east"
+
+	var requested []string
+	report, err := NewFromSourceFunc(&req, &config.Config{}, func(stationReq *model.Request) ReportSource {
+		requested = append(requested, stationReq.StationID)
+		src, err := fixture.Load(fixtureDir, stationReq)
+		suite.NoError(err)
+		return src
+	}, nil)
+	suite.NoError(err)
+
+	suite.NoError(report.Create(context.Background()))
+	suite.Equal(req.StationIDs, requested)
+	suite.Equal("east_StationReport_2018-08.xlsx", report.getFileName())
+
+	dir, err := ioutil.TempDir("", "fuelsale")
+	suite.NoError(err)
+	defer os.RemoveAll(dir)
+
+	fp, err := report.SaveToDisk(dir)
+	suite.NoError(err)
+	file, err := excelize.OpenFile(fp)
+	suite.NoError(err)
+
+	var sheets []string
+	for i := 1; i <= file.SheetCount; i++ {
+		sheets = append(sheets, file.GetSheetName(i))
+	}
+	// Fixtures return the same station name for every station
+	suite.Equal([]string{
+		"Summary",
+		"No-Lead Fuel Sales by Station",
+		"Diesel Fuel Sales by Station",
+		"Test Station Sales",
+		"Test Station Delivery",
+		"Test Station OS Month",
+		"Test Station OS Annual",
+		"Test Station Sales (2)",
+		"Test Station Delivery (2)",
+		"Test Station OS Month (2)",
+		"Test Station OS Annual (2)",
+	}, sheets)
+	suite.Equal("Group Total", file.GetCellValue("Summary", "A6"))
+	suite.Equal("SUM(B4:B5)", file.GetCellFormula("Summary", "B6"))
+	suite.Equal("SUM(B4:E4)", file.GetCellFormula("Summary", "F4"))
+	suite.Contains(file.GetCellFormula("Summary", "B4"), "'Test Station Sales'!")
+	suite.Contains(file.GetCellFormula("Summary", "B5"), "'Test Station Sales (2)'!")
+}
+
+// TestCreateGroupSections method
+func (suite *FixtureSuite) TestCreateGroupSections() {
+	req := *suite.report.request
+	req.StationID = ""
+	req.StationIDs = []string{stationID, "449d51e8-7e30-4ea2-8ba0-4f6bd3fbbf1e"}
+	req.Sections = []string{model.SectionFuelSalesNL, model.SectionOverShortAnnual}
+
+	report, err := NewFromSourceFunc(&req, &config.Config{}, func(stationReq *model.Request) ReportSource {
+		src, err := fixture.Load(fixtureDir, stationReq)
+		suite.NoError(err)
+		return src
+	}, nil)
+	suite.NoError(err)
+	var written []string
+	report.SetProgress(func(section string) { written = append(written, section) })
+	suite.NoError(report.Create(context.Background()))
+	suite.Equal(req.Sections, written)
+
+	dir, err := ioutil.TempDir("", "fuelsale")
+	suite.NoError(err)
+	defer os.RemoveAll(dir)
+	fp, err := report.SaveToDisk(dir)
+	suite.NoError(err)
+	file, err := excelize.OpenFile(fp)
+	suite.NoError(err)
+
+	var sheets []string
+	for i := 1; i <= file.SheetCount; i++ {
+		sheets = append(sheets, file.GetSheetName(i))
+	}
+	suite.Equal([]string{
+		"No-Lead Fuel Sales by Station",
+		"Test Station OS Annual",
+		"Test Station OS Annual (2)",
+	}, sheets)
+}
+
+// TestCreateSections method
+func (suite *FixtureSuite) TestCreateSections() {
+	req := *suite.report.request
+	req.Sections = []string{model.SectionFuelDelivery, model.SectionOverShortMonth}
+	src, err := fixture.Load(fixtureDir, &req)
+	suite.NoError(err)
+	counter := &countingSource{ReportSource: src}
+
+	report, err := New(&req, &config.Config{}, counter, nil)
+	suite.NoError(err)
+	suite.NoError(report.Create(context.Background()))
+	suite.Equal(map[string]int{"FuelDelivery": 1, "OverShortMonth": 1}, counter.calls)
+	suite.Equal("Test Station_StationReport_2018-08.xlsx", report.getFileName())
+
+	dir, err := ioutil.TempDir("", "fuelsale")
+	suite.NoError(err)
+	defer os.RemoveAll(dir)
+	fp, err := report.SaveToDisk(dir)
+	suite.NoError(err)
+	file, err := excelize.OpenFile(fp)
+	suite.NoError(err)
+	suite.Equal(2, file.SheetCount)
+	suite.Equal("Fuel Delivery", file.GetSheetName(1))
+	suite.Equal("Over-Short Month", file.GetSheetName(2))
+
+	// Sheets follow the requested order
+	req.Sections = []string{model.SectionOverShortMonth, model.SectionFuelDelivery}
+	report, err = New(&req, &config.Config{}, src, nil)
+	suite.NoError(err)
+	suite.NoError(report.Create(context.Background()))
+	fp, err = report.SaveToDisk(dir)
+	suite.NoError(err)
+	file, err = excelize.OpenFile(fp)
+	suite.NoError(err)
+	suite.Equal("Over-Short Month", file.GetSheetName(1))
+}
+
+// TestCreateCSV method
+func (suite *FixtureSuite) TestCreateCSV() {
+	req := *suite.report.request
+	req.Sections = []string{model.SectionFuelSalesNL, model.SectionFuelDelivery}
+	req.Format = model.FormatCSV
+
+	report, err := NewFromSourceFunc(&req, &config.Config{}, func(stationReq *model.Request) ReportSource {
+		src, err := fixture.Load(fixtureDir, stationReq)
+		suite.NoError(err)
+		return src
+	}, nil)
+	suite.NoError(err)
+	suite.NoError(report.Create(context.Background()))
+	suite.Equal("Test Station_StationReport_2018-08.zip", report.getFileName())
+
+	buf, err := report.file.OutputFile()
+	suite.NoError(err)
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	suite.NoError(err)
+
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	suite.Equal([]string{
+		"No-Lead Fuel Sales by Station.csv",
+		"Fuel Delivery.csv",
+	}, names)
+
+	// Only workbooks have the group summary
+	req.StationID = ""
+	req.StationIDs = []string{stationID, "449d51e8-7e30-4ea2-8ba0-4f6bd3fbbf1e"}
+	_, err = NewFromSourceFunc(&req, &config.Config{}, func(stationReq *model.Request) ReportSource {
+		return nil
+	}, nil)
+	suite.EqualError(err, "Group reports can't be created as csv, use xlsx")
+}
+
+// TestNewRequiresSourceFunc method
+func (suite *FixtureSuite) TestNewRequiresSourceFunc() {
+	_, err := NewFromSourceFunc(&model.Request{}, &config.Config{}, nil, nil)
+	suite.Error(err)
+
+	src, err := fixture.Load(fixtureDir, suite.report.request)
+	suite.NoError(err)
+	req := *suite.report.request
+	req.StartDate, req.EndDate = req.Date, req.Date.AddDate(0, 2, -1)
+	_, err = New(&req, &config.Config{}, src, nil)
+	suite.Error(err, "Expected date range to need a SourceFunc")
+}
+
+// TestURLExpiry method
+func (suite *FixtureSuite) TestURLExpiry() {
+	suite.Equal(defaultURLExpiry, suite.report.urlExpiry())
+
+	suite.report.cfg.URLExpiry = 30 * time.Minute
+	suite.report.cfg.URLExpiryMax = 2 * time.Hour
+	suite.Equal(30*time.Minute, suite.report.urlExpiry())
+
+	suite.report.request.URLExpiry = time.Hour
+	suite.Equal(time.Hour, suite.report.urlExpiry())
+
+	suite.report.request.URLExpiry = 48 * time.Hour
+	suite.Equal(2*time.Hour, suite.report.urlExpiry())
+	suite.Equal(defaultURLExpiry, URLExpiry(0, nil))
+}
+
+// TestDocuments method checks every output format renders each section and
+// refuses unknown sections and missing data alike
+func (suite *FixtureSuite) TestDocuments() {
+	sr, err := fixture.StationReport(fixtureDir, suite.report.request)
+	suite.NoError(err)
+
+	for _, format := range model.Formats {
+		doc, err := outputFormats[format].newDocument(suite.report.request)
+		suite.NoError(err, format)
+		for _, name := range model.Sections {
+			suite.NoError(doc.WriteSection(name, "", sr), "%s %s", format, name)
+		}
+		suite.Error(doc.WriteSection("unknown", "", sr), format)
+		suite.Error(doc.WriteSection(model.SectionFuelDelivery, "", &model.StationReport{}), "Expected missing data error for %s", format)
+
+		_, err = doc.OutputFile()
+		suite.NoError(err, format)
+	}
+}
+
+// batchSource struct answers combined queries from the fixtures and records
+// the sections each asked for, by station and month
+type batchSource struct {
+	*fixture.Source
+	req  *model.Request
+	mu   *sync.Mutex
+	asks map[string][]string
+}
+
+func (s *batchSource) StationReport(ctx context.Context) (*model.StationReport, error) {
+	s.mu.Lock()
+	s.asks[s.req.StationID+" "+s.req.Date.Format(timeFrmt)] = s.req.Sections
+	s.mu.Unlock()
+	return fixture.StationReport(fixtureDir, s.req)
+}
+
+// TestFetchStationsBatch method
+func (suite *FixtureSuite) TestFetchStationsBatch() {
+	var mu sync.Mutex
+	asks := make(map[string][]string)
+	cfg := &config.Config{}
+	cfg.GraphqlBatch = true
+
+	req := &model.Request{
+		Date:       time.Date(2018, time.July, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:    time.Date(2018, time.August, 31, 0, 0, 0, 0, time.UTC),
+		StartDate:  time.Date(2018, time.July, 1, 0, 0, 0, 0, time.UTC),
+		StationIDs: []string{"first", "second"},
+	}
+	newSource := func(stationReq *model.Request) ReportSource {
+		src, err := fixture.Load(fixtureDir, stationReq)
+		suite.NoError(err)
+		return &batchSource{Source: src, req: stationReq, mu: &mu, asks: asks}
+	}
+	r, err := NewFromSourceFunc(req, cfg, newSource, nil)
+	suite.NoError(err)
+
+	_, err = r.fetchStations(context.Background())
+	suite.NoError(err)
+
+	monthly := []string{model.SectionFuelSales, model.SectionFuelDelivery, model.SectionOverShortMonth}
+	shared := []string{model.SectionFuelSales, model.SectionFuelSalesNL, model.SectionFuelSalesDSL, model.SectionFuelDelivery, model.SectionOverShortMonth}
+	suite.Equal(map[string][]string{
+		"first 2018-07":  shared,
+		"first 2018-08":  model.Sections,
+		"second 2018-07": monthly,
+		"second 2018-08": append(monthly, model.SectionOverShortAnnual),
+	}, asks, "Expected the station list for the first station and the annual report for the year's last month only")
+
+	// Nothing is asked of stations that add nothing
+	for k := range asks {
+		delete(asks, k)
+	}
+	req.Sections = []string{model.SectionFuelSalesNL}
+	r, err = NewFromSourceFunc(req, cfg, newSource, nil)
+	suite.NoError(err)
+	_, err = r.fetchStations(context.Background())
+	suite.NoError(err)
+	suite.Len(asks, 2)
+	suite.NotContains(asks, "second 2018-07")
+}
+
+// TestNewMissingSource method
+func (suite *FixtureSuite) TestNewMissingSource() {
+	_, err := New(&model.Request{}, &config.Config{}, nil, nil)
+	suite.Error(err)
+}
+
+// countingSource struct records the queries made of a ReportSource
+type countingSource struct {
+	ReportSource
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (s *countingSource) count(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.calls == nil {
+		s.calls = make(map[string]int)
+	}
+	s.calls[name]++
+}
+
+func (s *countingSource) FuelSales(ctx context.Context) (*model.FuelSales, error) {
+	s.count("FuelSales")
+	return s.ReportSource.FuelSales(ctx)
+}
+
+func (s *countingSource) FuelSalesList(ctx context.Context) (*model.FuelSalesList, error) {
+	s.count("FuelSalesList")
+	return s.ReportSource.FuelSalesList(ctx)
+}
+
+func (s *countingSource) FuelDelivery(ctx context.Context) (*model.FuelDelivery, error) {
+	s.count("FuelDelivery")
+	return s.ReportSource.FuelDelivery(ctx)
+}
+
+func (s *countingSource) OverShortMonth(ctx context.Context) (*model.OverShortMonth, error) {
+	s.count("OverShortMonth")
+	return s.ReportSource.OverShortMonth(ctx)
+}
+
+func (s *countingSource) OverShortAnnual(ctx context.Context) (*model.OverShortAnnual, error) {
+	s.count("OverShortAnnual")
+	return s.ReportSource.OverShortAnnual(ctx)
+}
+
+// TestFixtureSuite function
+func TestFixtureSuite(t *testing.T) {
+	suite.Run(t, new(FixtureSuite))
+}
@@ -0,0 +1,86 @@
+package fuelsale
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/pulpfree/gdps-fs-dwnld/model"
+)
+
+const defaultGroupName = "Stations"
+
+// isGroup method
+func (r *Report) isGroup() bool {
+	return len(r.request.StationIDs) > 0
+}
+
+// writeGroup method writes the group summary, the shared sections and then
+// each station's own sections, in request order
+func (r *Report) writeGroup(srs []*model.StationReport) (err error) {
+
+	r.setFileName(r.groupName())
+
+	if gs, ok := r.file.(groupSummarizer); ok && r.wantsSummary() {
+		if err = gs.GroupSummary(r.groupName(), srs); err != nil {
+			return err
+		}
+	}
+
+	var stationSections []string
+	for _, name := range r.sections {
+		s, ok := model.LookupSection(name)
+		if !ok {
+			return fmt.Errorf("Unknown report section %s", name)
+		}
+		if !s.Group.Shared {
+			stationSections = append(stationSections, name)
+			continue
+		}
+		if err = r.file.WriteSection(name, "", srs[0]); err != nil {
+			return err
+		}
+		r.sectionDone(name)
+	}
+
+	for _, sr := range srs {
+		nm := r.stationName(sr)
+		for _, name := range stationSections {
+			s, _ := model.LookupSection(name)
+			if err = r.file.WriteSection(name, nm+" "+s.Group.StationTitle, sr); err != nil {
+				return err
+			}
+		}
+	}
+	// Station sections are only complete once written for every station
+	for _, name := range stationSections {
+		r.sectionDone(name)
+	}
+
+	return err
+}
+
+// wantsSummary method reports whether any section totalled on the group
+// summary was requested
+func (r *Report) wantsSummary() bool {
+	return r.request.HasSection(model.SectionFuelSales) ||
+		r.request.HasSection(model.SectionFuelDelivery) ||
+		r.request.HasSection(model.SectionOverShortMonth)
+}
+
+// groupName method returns the requested station group or a generic name
+// when the stations were listed individually
+func (r *Report) groupName() string {
+	if r.request.StationGroup != "" {
+		return r.request.StationGroup
+	}
+	return defaultGroupName
+}
+
+// groupKey method identifies a group report by its name and its stations in
+// the order requested, as they decide the titles, file name and tab order
+func (r *Report) groupKey() string {
+	sum := sha256.Sum256([]byte(r.groupName() + ":" + strings.Join(r.request.StationIDs, ",")))
+	return "group-" + hex.EncodeToString(sum[:])[:12]
+}
@@ -0,0 +1,67 @@
+package fuelsale
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/pulpfree/gdps-fs-dwnld/csv"
+	"github.com/pulpfree/gdps-fs-dwnld/jsonreport"
+	"github.com/pulpfree/gdps-fs-dwnld/model"
+	"github.com/pulpfree/gdps-fs-dwnld/pdf"
+	"github.com/pulpfree/gdps-fs-dwnld/xlsx"
+)
+
+// Document interface is implemented by each output format
+type Document interface {
+	WriteSection(name, title string, sr *model.StationReport) error
+	OutputFile() (bytes.Buffer, error)
+	OutputToDisk(path string) (string, error)
+}
+
+// groupSummarizer interface is implemented by formats with a group summary,
+// see xlsx.XLSX GroupSummary
+type groupSummarizer interface {
+	GroupSummary(groupName string, srs []*model.StationReport) error
+}
+
+// outputFormat struct
+type outputFormat struct {
+	ext         string
+	contentType string
+	newDocument func(req *model.Request) (Document, error)
+}
+
+// outputFormats maps each model.Formats value to its document
+var outputFormats = map[string]outputFormat{
+	model.FormatXLSX: {"xlsx", xlsx.ContentType, newXLSX},
+	model.FormatCSV:  {"zip", csv.ContentType, func(*model.Request) (Document, error) { return csv.NewFile() }},
+	model.FormatPDF:  {"pdf", pdf.ContentType, func(*model.Request) (Document, error) { return pdf.NewFile() }},
+	model.FormatJSON: {"json", jsonreport.ContentType, func(*model.Request) (Document, error) { return jsonreport.NewFile() }},
+}
+
+// newXLSX function returns a workbook with charts unless the request turns them off
+func newXLSX(req *model.Request) (Document, error) {
+	x, err := xlsx.NewFile()
+	if err != nil {
+		return nil, err
+	}
+	x.SetCharts(!req.NoCharts)
+	return x, nil
+}
+
+// output method returns the requested output format, xlsx when none was set
+func (r *Report) output() (outputFormat, error) {
+	name := r.request.Format
+	if name == "" {
+		name = model.FormatXLSX
+	}
+	f, ok := outputFormats[name]
+	if !ok {
+		return f, fmt.Errorf("Unknown report format %s", name)
+	}
+	if r.isGroup() && !model.IsGroupFormat(name) {
+		return f, fmt.Errorf("Group reports can't be created as %s, use %s", name, strings.Join(model.GroupFormats, " or "))
+	}
+	return f, nil
+}
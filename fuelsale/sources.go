@@ -0,0 +1,204 @@
+package fuelsale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pulpfree/gdps-fs-dwnld/config"
+	"github.com/pulpfree/gdps-fs-dwnld/graphql"
+	"github.com/pulpfree/gdps-fs-dwnld/model"
+	"github.com/pulpfree/gdps-fs-dwnld/storage"
+)
+
+// ForRequest function creates the report for req from the GDPS API queried with
+// authToken. Several stations or a date range take a query per station and month.
+func ForRequest(req *model.Request, cfg *config.Config, authToken string, store storage.Storage) (*Report, error) {
+
+	if len(req.StationIDs) > 0 || req.IsRange() {
+		return NewFromSourceFunc(req, cfg, func(stationReq *model.Request) ReportSource {
+			return graphql.New(stationReq, cfg, authToken)
+		}, store)
+	}
+	return New(req, cfg, graphql.New(req, cfg, authToken), store)
+}
+
+// SourceFunc returns the ReportSource for a single station and month
+type SourceFunc func(req *model.Request) ReportSource
+
+// NewFromSourceFunc function creates a report that may cover several stations,
+// see model.Request StationIDs, or a date range. newSource is called for each
+// station and month the report needs.
+func NewFromSourceFunc(req *model.Request, cfg *config.Config, newSource SourceFunc, store storage.Storage) (r *Report, err error) {
+	if newSource == nil {
+		return nil, errors.New("Missing ReportSource")
+	}
+	r = &Report{
+		cfg:       cfg,
+		request:   req,
+		newSource: newSource,
+		store:     store,
+		sections:  req.SectionList(),
+	}
+	r.format, err = r.output()
+	if err != nil {
+		return nil, err
+	}
+	return r, err
+}
+
+// fetchStations method fetches every station and month as one set of tasks and
+// joins the months of a date range. The station list report covers all
+// stations so is only fetched once a month, and the annual report once a year.
+func (r *Report) fetchStations(ctx context.Context) (srs []*model.StationReport, err error) {
+
+	ids := r.stationIDs()
+	months := r.request.Months()
+	batch := r.cfg != nil && r.cfg.GraphqlBatch
+	need := r.dataNeeded()
+
+	// The annual report for the last month of each year covers the year to date
+	lastOfYear := make(map[int]bool)
+	for j, m := range months {
+		if j == len(months)-1 || months[j+1].Year() != m.Year() {
+			lastOfYear[j] = true
+		}
+	}
+
+	// parts holds the report for each station and month
+	parts := make([][]*model.StationReport, len(ids))
+	var tasks []fetchTask
+	for i, id := range ids {
+		parts[i] = make([]*model.StationReport, len(months))
+
+		for j, m := range months {
+			name := fmt.Sprintf("%s %s", id, m.Format(timeFrmt))
+			stationReq := r.stationRequest(id, m)
+			if batch {
+				stationReq.Sections = r.batchSections(i == 0, lastOfYear[j])
+			}
+			src := r.newSource(stationReq)
+			if src == nil {
+				return nil, fmt.Errorf("Missing ReportSource for station %s", id)
+			}
+
+			if client, ok := src.(StationReporter); ok && batch {
+				if len(stationReq.Sections) == 0 {
+					parts[i][j] = new(model.StationReport)
+					continue
+				}
+				i, j := i, j
+				tasks = append(tasks, fetchTask{"StationReport " + name, func(ctx context.Context) (err error) {
+					parts[i][j], err = client.StationReport(ctx)
+					return err
+				}})
+				continue
+			}
+
+			parts[i][j] = new(model.StationReport)
+			for _, q := range queries {
+				if !need[q.data] || (q.shared && i > 0) || (q.yearly && !lastOfYear[j]) {
+					continue
+				}
+				tasks = append(tasks, queryTask(q, q.name+" "+name, src, parts[i][j]))
+			}
+		}
+	}
+
+	if err = runTasks(ctx, r.fetchConcurrency(), tasks); err != nil {
+		return nil, err
+	}
+
+	// Every station shares the first station's list
+	p := r.period()
+	var lists []*model.FuelSalesList
+	for _, sr := range parts[0] {
+		if sr.FuelSalesList != nil {
+			lists = append(lists, sr.FuelSalesList)
+		}
+	}
+
+	srs = make([]*model.StationReport, len(ids))
+	for i := range ids {
+		if !r.request.IsRange() {
+			srs[i] = parts[i][0]
+		} else {
+			srs[i] = stitchStation(p, parts[i], lastOfYear)
+		}
+		srs[i].FuelSalesList = nil
+		if len(lists) == 1 {
+			srs[i].FuelSalesList = lists[0]
+		} else if len(lists) > 1 {
+			if i == 0 {
+				srs[i].FuelSalesList = stitchFuelSalesList(p, lists)
+			} else {
+				srs[i].FuelSalesList = srs[0].FuelSalesList
+			}
+		}
+	}
+
+	return srs, err
+}
+
+// stationIDs method returns the stations the report covers
+func (r *Report) stationIDs() []string {
+	if r.isGroup() {
+		return r.request.StationIDs
+	}
+	return []string{r.request.StationID}
+}
+
+// stationRequest method returns a copy of the request for a single station and month
+func (r *Report) stationRequest(stationID string, month time.Time) *model.Request {
+	req := *r.request
+	req.Date = month
+	req.StartDate, req.EndDate = time.Time{}, time.Time{}
+	req.StationID = stationID
+	req.StationIDs = nil
+	return &req
+}
+
+// batchSections method returns the sections a combined query for one station
+// and month asks for. As with single queries, data shared by every station is
+// only fetched for the first and the annual report for the last month of each year.
+func (r *Report) batchSections(first, lastOfYear bool) (sections []string) {
+	for _, name := range r.sections {
+		s, _ := model.LookupSection(name)
+		q, ok := queryFor(s.Data)
+		if !ok || (q.shared && !first) || (q.yearly && !lastOfYear) {
+			continue
+		}
+		sections = append(sections, name)
+	}
+	return sections
+}
+
+// period method returns the days the report covers
+func (r *Report) period() period {
+	if r.request.IsRange() {
+		return period{r.request.StartDate, r.request.EndDate}
+	}
+	first := time.Date(r.request.Date.Year(), r.request.Date.Month(), 1, 0, 0, 0, 0, r.request.Date.Location())
+	return period{first, first.AddDate(0, 1, -1)}
+}
+
+// periodName method formats the report period for file names and storage keys:
+// a month, a span of months or, when not aligned to months, a span of days
+func (r *Report) periodName() string {
+
+	if !r.request.IsRange() {
+		return r.request.Date.Format(timeFrmt)
+	}
+
+	p := r.period()
+	first := time.Date(p.start.Year(), p.start.Month(), 1, 0, 0, 0, 0, p.start.Location())
+	last := time.Date(p.end.Year(), p.end.Month()+1, 0, 0, 0, 0, 0, p.end.Location())
+	if p.start.Equal(first) && dayInt(p.end) == dayInt(last) {
+		if p.start.Format(timeFrmt) == p.end.Format(timeFrmt) {
+			return p.start.Format(timeFrmt)
+		}
+		return p.start.Format(timeFrmt) + "_" + p.end.Format(timeFrmt)
+	}
+	return p.start.Format(dateFrmt) + "_" + p.end.Format(dateFrmt)
+}
@@ -0,0 +1,301 @@
+package fuelsale
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/pulpfree/gdps-fs-dwnld/model"
+)
+
+const dayFrmt = "20060102"
+
+// period struct is the span of days a date range report covers. Months fully
+// inside the span keep the summaries returned by the API, partial months are
+// trimmed to the span and their summaries recomputed from the daily rows.
+type period struct {
+	start, end time.Time
+}
+
+// contains method reports whether the day given as YYYYMMDD is inside the period
+func (p period) contains(day int64) bool {
+	return day >= dayInt(p.start) && day <= dayInt(p.end)
+}
+
+// fullMonth method reports whether the whole month of date is inside the period
+func (p period) fullMonth(date time.Time) bool {
+	first := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
+	last := first.AddDate(0, 1, -1)
+	return dayInt(first) >= dayInt(p.start) && dayInt(last) <= dayInt(p.end)
+}
+
+// containsMonth method reports whether any day of the month given as YYYYMM is inside the period
+func (p period) containsMonth(month string) bool {
+	return month >= p.start.Format("200601") && month <= p.end.Format("200601")
+}
+
+func dayInt(t time.Time) int64 {
+	n, _ := strconv.ParseInt(t.Format(dayFrmt), 10, 64)
+	return n
+}
+
+// stitchStation function joins the monthly reports of a station. Only the
+// annual reports for the last month of each year are used.
+func stitchStation(p period, months []*model.StationReport, lastOfYear map[int]bool) *model.StationReport {
+
+	var (
+		fs  []*model.FuelSales
+		fd  []*model.FuelDelivery
+		osm []*model.OverShortMonth
+		osa []*model.OverShortAnnual
+	)
+	for j, m := range months {
+		if m.FuelSales != nil {
+			fs = append(fs, m.FuelSales)
+		}
+		if m.FuelDelivery != nil {
+			fd = append(fd, m.FuelDelivery)
+		}
+		if m.OverShortMonth != nil {
+			osm = append(osm, m.OverShortMonth)
+		}
+		if m.OverShortAnnual != nil && lastOfYear[j] {
+			osa = append(osa, m.OverShortAnnual)
+		}
+	}
+
+	sr := new(model.StationReport)
+	if len(fs) > 0 {
+		sr.FuelSales = stitchFuelSales(p, fs)
+	}
+	if len(fd) > 0 {
+		sr.FuelDelivery = stitchFuelDelivery(p, fd)
+	}
+	if len(osm) > 0 {
+		sr.OverShortMonth = stitchOverShortMonth(p, osm)
+	}
+	if len(osa) > 0 {
+		sr.OverShortAnnual = stitchOverShortAnnual(p, osa)
+	}
+	return sr
+}
+
+// stitchFuelSales function joins monthly fuel sales into continuous daily rows
+func stitchFuelSales(p period, parts []*model.FuelSales) *model.FuelSales {
+
+	res := new(model.FuelSales)
+	res.Date, res.EndDate = p.start, p.end
+	res.Station = parts[0].Station
+	res.Report.SalesSummary = make(map[string]float64)
+
+	var fts [][]string
+	for _, part := range parts {
+		fts = append(fts, part.Report.FuelTypes)
+		full := p.fullMonth(part.Date)
+		for _, row := range part.Report.StationSales {
+			if !p.contains(row.Date) {
+				continue
+			}
+			res.Report.StationSales = append(res.Report.StationSales, row)
+			if !full {
+				for ft, val := range row.Sales {
+					res.Report.SalesSummary[ft] += val
+					res.Report.SalesTotal += val
+				}
+			}
+		}
+		if full {
+			for ft, val := range part.Report.SalesSummary {
+				res.Report.SalesSummary[ft] += val
+			}
+			res.Report.SalesTotal += part.Report.SalesTotal
+		}
+	}
+	res.Report.FuelTypes = unionFuelTypes(fts...)
+
+	return res
+}
+
+// stitchFuelDelivery function joins monthly deliveries into continuous daily rows
+func stitchFuelDelivery(p period, parts []*model.FuelDelivery) *model.FuelDelivery {
+
+	res := new(model.FuelDelivery)
+	res.Date, res.EndDate = p.start, p.end
+	res.Station = parts[0].Station
+	res.Report.DeliverySummary = make(map[string]float64)
+
+	var fts [][]string
+	for _, part := range parts {
+		fts = append(fts, part.Report.FuelTypes)
+		full := p.fullMonth(part.Date)
+		for _, row := range part.Report.Deliveries {
+			if !p.contains(row.Date) {
+				continue
+			}
+			res.Report.Deliveries = append(res.Report.Deliveries, row)
+			if !full {
+				for ft, val := range row.Data {
+					res.Report.DeliverySummary[ft] += float64(val)
+				}
+			}
+		}
+		if full {
+			for ft, val := range part.Report.DeliverySummary {
+				res.Report.DeliverySummary[ft] += val
+			}
+		}
+	}
+	res.Report.FuelTypes = unionFuelTypes(fts...)
+
+	return res
+}
+
+// stitchOverShortMonth function joins monthly over-short reports into
+// continuous daily rows
+func stitchOverShortMonth(p period, parts []*model.OverShortMonth) *model.OverShortMonth {
+
+	res := new(model.OverShortMonth)
+	res.Date, res.EndDate = p.start, p.end
+	res.Station = parts[0].Station
+	res.Report.OverShortSummary = make(map[string]float64)
+
+	var fts [][]string
+	for _, part := range parts {
+		fts = append(fts, part.Report.FuelTypes)
+		full := p.fullMonth(part.Date)
+		for _, row := range part.Report.OverShort {
+			if !p.contains(row.Date) {
+				continue
+			}
+			res.Report.OverShort = append(res.Report.OverShort, row)
+			if !full {
+				for ft, val := range row.Data {
+					res.Report.OverShortSummary[ft] += val.OverShort
+				}
+			}
+		}
+		if full {
+			for ft, val := range part.Report.OverShortSummary {
+				res.Report.OverShortSummary[ft] += val
+			}
+		}
+	}
+	res.Report.FuelTypes = unionFuelTypes(fts...)
+
+	return res
+}
+
+// stitchOverShortAnnual function keeps the months of each yearly report that
+// fall inside the period, so a range may cross a year end
+func stitchOverShortAnnual(p period, parts []*model.OverShortAnnual) *model.OverShortAnnual {
+
+	res := new(model.OverShortAnnual)
+	res.Date, res.EndDate = p.start, p.end
+	res.Station = parts[0].Station
+	res.Report.Year = p.start.Year()
+	res.Report.Months = make(map[string]map[string]float64)
+	res.Report.Summary = make(map[string]float64)
+
+	var fts [][]string
+	for _, part := range parts {
+		fts = append(fts, part.Report.FuelTypes)
+		for month, vals := range part.Report.Months {
+			if !p.containsMonth(month) {
+				continue
+			}
+			res.Report.Months[month] = vals
+		}
+	}
+	for _, vals := range res.Report.Months {
+		for ft, val := range vals {
+			res.Report.Summary[ft] += val
+		}
+	}
+	res.Report.FuelTypes = unionFuelTypes(fts...)
+
+	return res
+}
+
+// stitchFuelSalesList function joins the weekly station lists of each month.
+// Weeks spanning a month end appear in both months and are only kept once.
+func stitchFuelSalesList(p period, parts []*model.FuelSalesList) *model.FuelSalesList {
+
+	res := new(model.FuelSalesList)
+	res.Date, res.EndDate = p.start, p.end
+
+	weeks := make(map[string]bool)
+	var stationIDs []string
+	stations := make(map[string]*model.StationPeriodSales)
+	periods := make(map[string]map[string]int) // station, week to index in Periods
+
+	for _, part := range parts {
+		for _, hdr := range part.Report.PeriodHeader {
+			if !weeks[hdr.YearWeek] {
+				weeks[hdr.YearWeek] = true
+				res.Report.PeriodHeader = append(res.Report.PeriodHeader, hdr)
+			}
+		}
+
+		for _, ps := range part.Report.PeriodSales {
+			st, ok := stations[ps.StationID]
+			if !ok {
+				st = &model.StationPeriodSales{StationID: ps.StationID, StationName: ps.StationName}
+				st.FuelPrices = ps.FuelPrices
+				st.FuelPrices.Prices = make(map[string]float64)
+				stations[ps.StationID] = st
+				periods[ps.StationID] = make(map[string]int)
+				stationIDs = append(stationIDs, ps.StationID)
+			}
+			for wk, price := range ps.FuelPrices.Prices {
+				if _, ok := st.FuelPrices.Prices[wk]; !ok {
+					st.FuelPrices.Prices[wk] = price
+				}
+			}
+			for _, per := range ps.Periods {
+				if _, ok := periods[ps.StationID][per.Dates.YearWeek]; ok {
+					continue
+				}
+				periods[ps.StationID][per.Dates.YearWeek] = len(st.Periods)
+				st.Periods = append(st.Periods, per)
+			}
+		}
+	}
+
+	// Align every station's periods with the combined header, filling weeks a
+	// station had no report for
+	for _, id := range stationIDs {
+		st := stations[id]
+		aligned := make([]model.PeriodSales, len(res.Report.PeriodHeader))
+
+		st.StationTotal = make(map[string]float64)
+		for i, hdr := range res.Report.PeriodHeader {
+			if idx, ok := periods[id][hdr.YearWeek]; ok {
+				aligned[i] = st.Periods[idx]
+			} else {
+				aligned[i].Dates.YearWeek = hdr.YearWeek
+			}
+			for ft, val := range aligned[i].FuelSales {
+				st.StationTotal[ft] += val
+			}
+		}
+		st.Periods = aligned
+		res.Report.PeriodSales = append(res.Report.PeriodSales, *st)
+	}
+
+	return res
+}
+
+// unionFuelTypes function returns every fuel type in fts, in FuelTypes order
+func unionFuelTypes(fts ...[]string) []string {
+
+	seen := make(map[string]bool)
+	var all []string
+	for _, list := range fts {
+		for _, ft := range list {
+			if !seen[ft] {
+				seen[ft] = true
+				all = append(all, ft)
+			}
+		}
+	}
+	return model.SortFuelTypes(all)
+}
@@ -0,0 +1,120 @@
+package fuelsale
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pulpfree/gdps-fs-dwnld/config"
+	"github.com/pulpfree/gdps-fs-dwnld/fixture"
+	"github.com/pulpfree/gdps-fs-dwnld/model"
+	"github.com/stretchr/testify/suite"
+)
+
+// StitchSuite struct
+type StitchSuite struct {
+	suite.Suite
+	ctx context.Context
+	src *fixture.Source
+}
+
+// SetupTest method
+func (suite *StitchSuite) SetupTest() {
+	var err error
+	suite.ctx = context.Background()
+	suite.src, err = fixture.Load(fixtureDir, &model.Request{Date: suite.day("2018-08-01")})
+	suite.NoError(err)
+}
+
+// TestFuelSalesFullMonth method
+func (suite *StitchSuite) TestFuelSalesFullMonth() {
+	fs, err := suite.src.FuelSales(suite.ctx)
+	suite.NoError(err)
+
+	res := stitchFuelSales(period{suite.day("2018-08-01"), suite.day("2018-08-31")}, []*model.FuelSales{fs})
+	suite.Len(res.Report.StationSales, 31)
+	suite.Equal(fs.Report.SalesSummary, res.Report.SalesSummary)
+	suite.Equal(fs.Report.SalesTotal, res.Report.SalesTotal)
+	suite.Equal(suite.day("2018-08-31"), res.EndDate)
+}
+
+// TestFuelSalesPartialMonth method
+func (suite *StitchSuite) TestFuelSalesPartialMonth() {
+	fs, err := suite.src.FuelSales(suite.ctx)
+	suite.NoError(err)
+
+	res := stitchFuelSales(period{suite.day("2018-08-10"), suite.day("2018-08-20")}, []*model.FuelSales{fs})
+	suite.Len(res.Report.StationSales, 11)
+	suite.Equal(int64(20180810), res.Report.StationSales[0].Date)
+
+	var nl, total float64
+	for _, row := range res.Report.StationSales {
+		nl += row.Sales["NL"]
+		for _, val := range row.Sales {
+			total += val
+		}
+	}
+	suite.InDelta(nl, res.Report.SalesSummary["NL"], 0.001)
+	suite.InDelta(total, res.Report.SalesTotal, 0.001)
+}
+
+// TestFuelSalesListDedupesWeeks method
+func (suite *StitchSuite) TestFuelSalesListDedupesWeeks() {
+	fsl, err := suite.src.FuelSalesList(suite.ctx)
+	suite.NoError(err)
+
+	res := stitchFuelSalesList(period{suite.day("2018-08-01"), suite.day("2018-09-30")}, []*model.FuelSalesList{fsl, fsl})
+	suite.Equal(len(fsl.Report.PeriodHeader), len(res.Report.PeriodHeader))
+	suite.Equal(len(fsl.Report.PeriodSales), len(res.Report.PeriodSales))
+	for i, ps := range res.Report.PeriodSales {
+		suite.Len(ps.Periods, len(res.Report.PeriodHeader))
+		suite.InDelta(fsl.Report.PeriodSales[i].StationTotal["NL"], ps.StationTotal["NL"], 0.001)
+	}
+}
+
+// TestOverShortAnnualMonths method
+func (suite *StitchSuite) TestOverShortAnnualMonths() {
+	osa, err := suite.src.OverShortAnnual(suite.ctx)
+	suite.NoError(err)
+
+	res := stitchOverShortAnnual(period{suite.day("2018-02-15"), suite.day("2018-03-10")}, []*model.OverShortAnnual{osa})
+	suite.Len(res.Report.Months, 2)
+	suite.InDelta(osa.Report.Months["201802"]["NL"]+osa.Report.Months["201803"]["NL"], res.Report.Summary["NL"], 0.001)
+}
+
+// TestCreateRange method
+func (suite *StitchSuite) TestCreateRange() {
+	req := &model.Request{
+		Date:      suite.day("2018-07-01"),
+		StartDate: suite.day("2018-07-01"),
+		EndDate:   suite.day("2018-08-31"),
+		StationID: stationID,
+	}
+
+	var months []string
+	report, err := NewFromSourceFunc(req, &config.Config{}, func(monthReq *model.Request) ReportSource {
+		months = append(months, monthReq.Date.Format(timeFrmt))
+		src, err := fixture.Load(fixtureDir, monthReq)
+		suite.NoError(err)
+		return src
+	}, nil)
+	suite.NoError(err)
+
+	suite.NoError(report.Create(suite.ctx))
+	suite.Equal([]string{"2018-07", "2018-08"}, months)
+	suite.Equal("Test Station_StationReport_2018-07_2018-08.xlsx", report.getFileName())
+
+	req.StartDate = suite.day("2018-07-15")
+	suite.Equal("2018-07-15_2018-08-31", report.periodName())
+}
+
+func (suite *StitchSuite) day(s string) time.Time {
+	t, err := time.Parse(timeFormat, s)
+	suite.NoError(err)
+	return t
+}
+
+// TestStitchSuite function
+func TestStitchSuite(t *testing.T) {
+	suite.Run(t, new(StitchSuite))
+}
@@ -0,0 +1,114 @@
+package gdpstest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+const (
+	dynamoTargetPrefix = "DynamoDB_20120810."
+	dynamoErrorPrefix  = "com.amazonaws.dynamodb.v20120810#"
+)
+
+// DynamoServer struct is a fake DynamoDB answering PutItem and GetItem for
+// tables keyed by a single hash key. Point an aws.Config Endpoint at its URL.
+type DynamoServer struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	tables map[string]*dynamoTable
+}
+
+type dynamoTable struct {
+	key   string
+	items map[string]map[string]json.RawMessage
+}
+
+type dynamoRequest struct {
+	TableName string                     `json:"TableName"`
+	Item      map[string]json.RawMessage `json:"Item"`
+	Key       map[string]json.RawMessage `json:"Key"`
+}
+
+// NewDynamoServer function starts a server without any tables
+func NewDynamoServer() *DynamoServer {
+	s := &DynamoServer{tables: make(map[string]*dynamoTable)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// CreateTable method adds an empty table whose items are keyed by the attribute key
+func (s *DynamoServer) CreateTable(name, key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tables[name] = &dynamoTable{key: key, items: make(map[string]map[string]json.RawMessage)}
+}
+
+// Items method returns the number of items in the table
+func (s *DynamoServer) Items(name string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if t, ok := s.tables[name]; ok {
+		return len(t.items)
+	}
+	return 0
+}
+
+func (s *DynamoServer) handle(w http.ResponseWriter, r *http.Request) {
+
+	var req dynamoRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		dynamoError(w, "SerializationException", err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.tables[req.TableName]
+	if !ok {
+		dynamoError(w, "ResourceNotFoundException", "Requested resource not found")
+		return
+	}
+
+	switch strings.TrimPrefix(r.Header.Get("X-Amz-Target"), dynamoTargetPrefix) {
+	case "PutItem":
+		key, ok := req.Item[t.key]
+		if !ok {
+			dynamoError(w, "ValidationException", "Missing the key "+t.key+" in the item")
+			return
+		}
+		t.items[string(key)] = req.Item
+		writeDynamo(w, struct{}{})
+
+	case "GetItem":
+		key, ok := req.Key[t.key]
+		if !ok || len(req.Key) != 1 {
+			dynamoError(w, "ValidationException", "The provided key element does not match the schema")
+			return
+		}
+		item, ok := t.items[string(key)]
+		if !ok {
+			writeDynamo(w, struct{}{})
+			return
+		}
+		writeDynamo(w, map[string]interface{}{"Item": item})
+
+	default:
+		dynamoError(w, "UnknownOperationException", "Unsupported operation "+r.Header.Get("X-Amz-Target"))
+	}
+}
+
+func writeDynamo(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+	json.NewEncoder(w).Encode(v)
+}
+
+func dynamoError(w http.ResponseWriter, code, msg string) {
+	w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(map[string]string{"__type": dynamoErrorPrefix + code, "message": msg})
+}
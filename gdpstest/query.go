@@ -0,0 +1,98 @@
+package gdpstest
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+// field struct is a top level selection, key is the alias if one is given
+type field struct {
+	key  string
+	name string
+}
+
+// parseFields function extracts the top level fields of a query's selection set.
+// It understands aliases, arguments and nested selections, which is all the
+// report queries use.
+func parseFields(query string) (fields []field, err error) {
+
+	start := strings.Index(query, "{")
+	if start < 0 {
+		return nil, errors.New("query has no selection set")
+	}
+
+	src := query[start+1:]
+	for i := 0; i < len(src); {
+		c := rune(src[i])
+		switch {
+		case unicode.IsSpace(c) || c == ',':
+			i++
+		case c == '}':
+			if len(fields) == 0 {
+				return nil, errors.New("query selects no fields")
+			}
+			return fields, nil
+		case isNameChar(c):
+			name, next := readName(src, i)
+			f := field{key: name, name: name}
+			i = skipSpace(src, next)
+			if i < len(src) && src[i] == ':' {
+				f.name, i = readName(src, skipSpace(src, i+1))
+				i = skipSpace(src, i)
+			}
+			if i < len(src) && src[i] == '(' {
+				if i, err = skipBlock(src, i, '(', ')'); err != nil {
+					return nil, err
+				}
+				i = skipSpace(src, i)
+			}
+			if i < len(src) && src[i] == '{' {
+				if i, err = skipBlock(src, i, '{', '}'); err != nil {
+					return nil, err
+				}
+			}
+			fields = append(fields, f)
+		default:
+			return nil, errors.New("unexpected character in query: " + string(c))
+		}
+	}
+
+	return nil, errors.New("unterminated selection set")
+}
+
+func isNameChar(c rune) bool {
+	return c == '_' || unicode.IsLetter(c) || unicode.IsDigit(c)
+}
+
+func readName(src string, i int) (string, int) {
+	start := i
+	for i < len(src) && isNameChar(rune(src[i])) {
+		i++
+	}
+	return src[start:i], i
+}
+
+func skipSpace(src string, i int) int {
+	for i < len(src) && unicode.IsSpace(rune(src[i])) {
+		i++
+	}
+	return i
+}
+
+// skipBlock function returns the index following the close matching the open at i
+func skipBlock(src string, i int, open, close byte) (int, error) {
+	depth := 0
+	for ; i < len(src); i++ {
+		switch src[i] {
+		case open:
+			depth++
+		case close:
+			depth--
+			if depth == 0 {
+				return i + 1, nil
+			}
+		}
+	}
+	return i, errors.New("unbalanced query")
+}
@@ -0,0 +1,50 @@
+package gdpstest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// QuerySuite struct
+type QuerySuite struct {
+	suite.Suite
+}
+
+// TestParseFields method
+func (suite *QuerySuite) TestParseFields() {
+	fields, err := parseFields(`
+    query StationReport($date: String!, $stationID: String!) {
+      station(stationID: $stationID) {
+        id
+        name
+      }
+      fuelSales: fuelSaleMonth(date: $date, stationID: $stationID) {
+        stationSales {
+          date
+        }
+      }
+      fuelSaleListReport(date: $date) { periodHeader { week } }
+    }
+  `)
+	suite.NoError(err)
+	suite.Equal([]field{
+		{key: "station", name: "station"},
+		{key: "fuelSales", name: "fuelSaleMonth"},
+		{key: "fuelSaleListReport", name: "fuelSaleListReport"},
+	}, fields)
+}
+
+// TestParseFieldsInvalid method
+func (suite *QuerySuite) TestParseFieldsInvalid() {
+	_, err := parseFields(`query { station(stationID: "1") { id }`)
+	suite.Error(err)
+
+	_, err = parseFields(`query`)
+	suite.Error(err)
+}
+
+// TestQuerySuite function
+func TestQuerySuite(t *testing.T) {
+	suite.Run(t, new(QuerySuite))
+}
@@ -0,0 +1,243 @@
+package gdpstest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Server struct is a fake GDPS GraphQL API answering queries from fixture files
+type Server struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	data        map[string]json.RawMessage
+	stations    map[string]station
+	latency     time.Duration
+	failures    []int
+	fieldErrors map[string]string
+	requests    int
+}
+
+type station struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type graphqlRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+type graphqlError struct {
+	Message string   `json:"message"`
+	Path    []string `json:"path,omitempty"`
+}
+
+// NewServer function loads every json file in fixtureDir and starts the server.
+// Each file holds the data portion of a GraphQL response.
+func NewServer(fixtureDir string) (s *Server, err error) {
+
+	s = &Server{
+		data:        make(map[string]json.RawMessage),
+		stations:    make(map[string]station),
+		fieldErrors: make(map[string]string),
+	}
+	if err = s.load(fixtureDir); err != nil {
+		return nil, err
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s, err
+}
+
+// SetLatency method delays every response by d
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = d
+}
+
+// FailNext method responds to the next requests with the given status codes, in order
+func (s *Server) FailNext(codes ...int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, codes...)
+}
+
+// SetFieldError method returns a GraphQL error for any query selecting field
+func (s *Server) SetFieldError(field, msg string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fieldErrors[field] = msg
+}
+
+// Requests method returns the number of requests received
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+// Reset method clears injected latency and errors and the request count
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = 0
+	s.failures = nil
+	s.fieldErrors = make(map[string]string)
+	s.requests = 0
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+
+	s.mu.Lock()
+	s.requests++
+	latency := s.latency
+	failCode := 0
+	if len(s.failures) > 0 {
+		failCode, s.failures = s.failures[0], s.failures[1:]
+	}
+	s.mu.Unlock()
+
+	if latency > 0 {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-time.After(latency):
+		}
+	}
+	if failCode > 0 {
+		http.Error(w, http.StatusText(failCode), failCode)
+		return
+	}
+
+	var req graphqlRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{
+			"errors": []graphqlError{{Message: err.Error()}},
+		})
+		return
+	}
+
+	fields, err := parseFields(req.Query)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{
+			"errors": []graphqlError{{Message: err.Error()}},
+		})
+		return
+	}
+
+	data := make(map[string]interface{}, len(fields))
+	var errs []graphqlError
+	for _, f := range fields {
+		val, msg := s.resolve(f.name, req.Variables)
+		if msg != "" {
+			errs = append(errs, graphqlError{Message: msg, Path: []string{f.key}})
+		}
+		data[f.key] = val
+	}
+
+	res := map[string]interface{}{"data": data}
+	if len(errs) > 0 {
+		res["errors"] = errs
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+// resolve method returns the value for a top level field, or an error message
+func (s *Server) resolve(field string, vars map[string]interface{}) (interface{}, string) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if msg, ok := s.fieldErrors[field]; ok {
+		return nil, msg
+	}
+
+	if field == "station" {
+		id, _ := vars["stationID"].(string)
+		st, ok := s.stations[id]
+		if !ok {
+			return nil, fmt.Sprintf("station %s not found", id)
+		}
+		return st, ""
+	}
+
+	val, ok := s.data[field]
+	if !ok {
+		return nil, fmt.Sprintf("Cannot query field %q on type \"Query\"", field)
+	}
+	return val, ""
+}
+
+// load method reads the fixture files and indexes their top level fields
+func (s *Server) load(dir string) (err error) {
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no fixture files found in %s", dir)
+	}
+
+	for _, fp := range files {
+		file, err := ioutil.ReadFile(fp)
+		if err != nil {
+			return err
+		}
+		var fields map[string]json.RawMessage
+		if err = json.Unmarshal(file, &fields); err != nil {
+			return fmt.Errorf("%s: %s", fp, err)
+		}
+		for k, v := range fields {
+			if k == "station" {
+				var st station
+				if err = json.Unmarshal(v, &st); err != nil {
+					return fmt.Errorf("%s: %s", fp, err)
+				}
+				s.stations[st.ID] = st
+				continue
+			}
+			s.data[k] = v
+		}
+	}
+
+	return s.loadListStations()
+}
+
+// loadListStations method adds the stations found in the fuelSaleListReport fixture
+func (s *Server) loadListStations() error {
+
+	raw, ok := s.data["fuelSaleListReport"]
+	if !ok {
+		return nil
+	}
+	var rpt struct {
+		PeriodSales []struct {
+			StationID   string
+			StationName string
+		}
+	}
+	if err := json.Unmarshal(raw, &rpt); err != nil {
+		return err
+	}
+	for _, ps := range rpt.PeriodSales {
+		if _, ok := s.stations[ps.StationID]; !ok {
+			s.stations[ps.StationID] = station{ID: ps.StationID, Name: ps.StationName}
+		}
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
@@ -6,11 +6,13 @@ require (
 	github.com/360EntSecGroup-Skylar/excelize v1.4.1
 	github.com/aws/aws-lambda-go v1.19.1
 	github.com/aws/aws-sdk-go v1.34.18
+	github.com/jung-kurt/gofpdf v1.16.2
 	github.com/machinebox/graphql v0.2.2
 	github.com/matryer/is v1.4.0 // indirect
 	github.com/pulpfree/lambda-go-auth v0.1.1
 	github.com/pulpfree/lambda-go-proxy-response v1.0.1
 	github.com/sirupsen/logrus v1.6.0
 	github.com/stretchr/testify v1.6.1
+	github.com/xeipuuv/gojsonschema v1.2.0
 	gopkg.in/yaml.v2 v2.3.0
 )
@@ -0,0 +1,54 @@
+package graphql
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+)
+
+// stationNotFound matches the GraphQL error given for an unknown stationID
+var stationNotFound = regexp.MustCompile(`(?i)station .*not found`)
+
+// TimeoutError struct is returned when a query exceeds its deadline
+type TimeoutError struct {
+	Section string
+	Timeout time.Duration
+	Err     error
+}
+
+func (e *TimeoutError) Error() string {
+	if e.Timeout > 0 {
+		return fmt.Sprintf("graphql %s query timed out after %s", e.Section, e.Timeout)
+	}
+	return fmt.Sprintf("graphql %s query timed out", e.Section)
+}
+
+// Unwrap method
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// StatusError struct is returned when the API responds with an error status code
+type StatusError struct {
+	Code int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("graphql server returned status %d %s", e.Code, http.StatusText(e.Code))
+}
+
+// NotFoundError struct is returned when the API doesn't know the requested station
+type NotFoundError struct {
+	Section string
+	Err     error
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("graphql %s query: %s", e.Section, e.Err)
+}
+
+// Unwrap method
+func (e *NotFoundError) Unwrap() error {
+	return e.Err
+}
@@ -2,6 +2,7 @@ package graphql
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -19,6 +20,8 @@ type Client struct {
 	hdrs    http.Header
 	client  *graphql.Client
 	request *model.Request
+	retry   RetryPolicy
+	timeout time.Duration
 }
 
 const timeLongFrmt = "2006-01-02"
@@ -31,17 +34,23 @@ func New(req *model.Request, cfg *config.Config, authToken string) (c *Client) {
 		hdrs.Add("Authorization", fmt.Sprintf("Bearer %s", authToken))
 	}
 
+	httpClient := &http.Client{
+		Transport: &statusTransport{next: http.DefaultTransport},
+	}
+
 	c = &Client{
-		client:  graphql.NewClient(cfg.GraphqlURI),
+		client:  graphql.NewClient(cfg.GraphqlURI, graphql.WithHTTPClient(httpClient)),
 		hdrs:    hdrs,
 		request: req,
+		retry:   NewRetryPolicy(cfg),
+		timeout: cfg.GraphqlTimeout,
 	}
 
 	return c
 }
 
 // FuelSales method
-func (c *Client) FuelSales() (rpt *model.FuelSales, err error) {
+func (c *Client) FuelSales(ctx context.Context) (rpt *model.FuelSales, err error) {
 
 	req := graphql.NewRequest(`
     query ($date: String!, $stationID: String!) {
@@ -63,23 +72,19 @@ func (c *Client) FuelSales() (rpt *model.FuelSales, err error) {
 
 	req.Var("date", formattedDate(c.request.Date))
 	req.Var("stationID", c.request.StationID)
-	req.Header = c.hdrs
-
-	ctx := context.Background()
-	err = c.client.Run(ctx, req, &rpt)
+	err = c.run(ctx, "FuelSales", req, &rpt)
 	if err != nil {
-		log.Errorf("error running graphql client: %s", err.Error())
 		return nil, err
 	}
 
 	rpt.Date = c.request.Date
-	rpt.Report.FuelTypes = sortFuelTypes(rpt.Report.FuelTypes)
+	rpt.Report.FuelTypes = model.SortFuelTypes(rpt.Report.FuelTypes)
 
 	return rpt, err
 }
 
 // FuelDelivery method
-func (c *Client) FuelDelivery() (rpt *model.FuelDelivery, err error) {
+func (c *Client) FuelDelivery(ctx context.Context) (rpt *model.FuelDelivery, err error) {
 
 	req := graphql.NewRequest(`
     query FuelDeliveryReport($date: String!, $stationID: String!) {
@@ -100,23 +105,19 @@ func (c *Client) FuelDelivery() (rpt *model.FuelDelivery, err error) {
 
 	req.Var("date", formattedDate(c.request.Date))
 	req.Var("stationID", c.request.StationID)
-	req.Header = c.hdrs
-
-	ctx := context.Background()
-	err = c.client.Run(ctx, req, &rpt)
+	err = c.run(ctx, "FuelDelivery", req, &rpt)
 	if err != nil {
-		log.Errorf("error running graphql client: %s", err.Error())
 		return nil, err
 	}
 
-	rpt.Report.FuelTypes = sortFuelTypes(rpt.Report.FuelTypes)
+	rpt.Report.FuelTypes = model.SortFuelTypes(rpt.Report.FuelTypes)
 	rpt.Date = c.request.Date
 
 	return rpt, err
 }
 
 // OverShortMonth method
-func (c *Client) OverShortMonth() (rpt *model.OverShortMonth, err error) {
+func (c *Client) OverShortMonth(ctx context.Context) (rpt *model.OverShortMonth, err error) {
 
 	req := graphql.NewRequest(`
     query DipOSMonthReport($date: String!, $stationID: String!) {
@@ -139,23 +140,19 @@ func (c *Client) OverShortMonth() (rpt *model.OverShortMonth, err error) {
 
 	req.Var("date", formattedDate(c.request.Date))
 	req.Var("stationID", c.request.StationID)
-	req.Header = c.hdrs
-
-	ctx := context.Background()
-	err = c.client.Run(ctx, req, &rpt)
+	err = c.run(ctx, "OverShortMonth", req, &rpt)
 	if err != nil {
-		log.Errorf("error running graphql client: %s", err.Error())
 		return nil, err
 	}
 
-	rpt.Report.FuelTypes = sortFuelTypes(rpt.Report.FuelTypes)
+	rpt.Report.FuelTypes = model.SortFuelTypes(rpt.Report.FuelTypes)
 	rpt.Date = c.request.Date
 
 	return rpt, err
 }
 
 // OverShortAnnual method
-func (c *Client) OverShortAnnual() (rpt *model.OverShortAnnual, err error) {
+func (c *Client) OverShortAnnual(ctx context.Context) (rpt *model.OverShortAnnual, err error) {
 
 	req := graphql.NewRequest(`
     query DipOSAnnualReport($date: String!, $stationID: String!) {
@@ -174,23 +171,19 @@ func (c *Client) OverShortAnnual() (rpt *model.OverShortAnnual, err error) {
 
 	req.Var("date", formattedDate(c.request.Date))
 	req.Var("stationID", c.request.StationID)
-	req.Header = c.hdrs
-
-	ctx := context.Background()
-	err = c.client.Run(ctx, req, &rpt)
+	err = c.run(ctx, "OverShortAnnual", req, &rpt)
 	if err != nil {
-		log.Errorf("error running graphql client: %s", err.Error())
 		return nil, err
 	}
 
-	rpt.Report.FuelTypes = sortFuelTypes(rpt.Report.FuelTypes)
+	rpt.Report.FuelTypes = model.SortFuelTypes(rpt.Report.FuelTypes)
 	rpt.Date = c.request.Date
 
 	return rpt, err
 }
 
 // FuelSalesList method
-func (c *Client) FuelSalesList() (rpt *model.FuelSalesList, err error) {
+func (c *Client) FuelSalesList(ctx context.Context) (rpt *model.FuelSalesList, err error) {
 
 	req := graphql.NewRequest(`
     query FuelSaleListReport($date: String!) {
@@ -231,12 +224,8 @@ func (c *Client) FuelSalesList() (rpt *model.FuelSalesList, err error) {
   `)
 
 	req.Var("date", formattedDate(c.request.Date))
-	req.Header = c.hdrs
-
-	ctx := context.Background()
-	err = c.client.Run(ctx, req, &rpt)
+	err = c.run(ctx, "FuelSalesList", req, &rpt)
 	if err != nil {
-		log.Errorf("error running graphql client: %s", err.Error())
 		return nil, err
 	}
 	rpt.Date = c.request.Date
@@ -244,6 +233,88 @@ func (c *Client) FuelSalesList() (rpt *model.FuelSalesList, err error) {
 	return rpt, err
 }
 
+// Station method looks up the station with id. A NotFoundError is returned
+// when the API doesn't know it.
+func (c *Client) Station(ctx context.Context, id string) (st *model.Station, err error) {
+
+	req := graphql.NewRequest(`
+    query ($stationID: String!) {
+      station(stationID: $stationID) {
+        id
+        name
+      }
+    }
+  `)
+
+	req.Var("stationID", id)
+	var resp struct {
+		Station *model.Station
+	}
+	err = c.run(ctx, "Station", req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Station == nil || resp.Station.ID == "" {
+		return nil, &NotFoundError{Section: "Station", Err: fmt.Errorf("station %s not found", id)}
+	}
+
+	return resp.Station, err
+}
+
+// run method executes req, retrying transient failures according to the
+// client's retry policy. Each attempt is bounded by the per-query timeout.
+func (c *Client) run(ctx context.Context, section string, req *graphql.Request, resp interface{}) (err error) {
+
+	req.Header = c.hdrs
+
+	for attempt := 1; attempt <= c.retry.MaxAttempts; attempt++ {
+		log.Infof("graphql %s query attempt %d of %d", section, attempt, c.retry.MaxAttempts)
+		err = c.runOnce(ctx, section, req, resp)
+		if err == nil {
+			if attempt > 1 {
+				log.Infof("graphql %s query succeeded on attempt %d", section, attempt)
+			}
+			return nil
+		}
+		if ctx.Err() != nil || !retryable(err) || attempt == c.retry.MaxAttempts {
+			break
+		}
+
+		log.Warnf("graphql %s query attempt %d of %d failed: %s", section, attempt, c.retry.MaxAttempts, err)
+		if wErr := c.retry.wait(ctx, attempt); wErr != nil {
+			break
+		}
+	}
+
+	log.Errorf("error running graphql %s query: %s", section, err.Error())
+	return err
+}
+
+// runOnce method makes a single attempt at req
+func (c *Client) runOnce(ctx context.Context, section string, req *graphql.Request, resp interface{}) (err error) {
+
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	var status int
+	err = c.client.Run(context.WithValue(ctx, statusKey{}, &status), req, resp)
+	// Error responses may not hold GraphQL at all so the status decides first
+	if sErr := statusError(status); sErr != nil {
+		err = sErr
+	}
+	switch {
+	case err == nil:
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		err = &TimeoutError{Section: section, Timeout: c.timeout, Err: err}
+	case stationNotFound.MatchString(err.Error()):
+		err = &NotFoundError{Section: section, Err: err}
+	}
+	return err
+}
+
 //
 // ======================== Helper Functions =============================== //
 //
@@ -269,18 +340,6 @@ func extractSaleKeys(m map[string]float64) (keys []string) {
 	return keys
 }
 
-// sortFuelTypes function
-func sortFuelTypes(fts []string) (ret []string) {
-	for _, ft := range model.FuelTypes {
-		for _, k := range fts {
-			if ft == k {
-				ret = append(ret, ft)
-			}
-		}
-	}
-	return ret
-}
-
 // formattedDate function
 func formattedDate(date time.Time) string {
 	return date.Format(timeLongFrmt)
@@ -1,11 +1,14 @@
 package graphql
 
 import (
+	"context"
+	"errors"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/pulpfree/gdps-fs-dwnld/config"
+	"github.com/pulpfree/gdps-fs-dwnld/gdpstest"
 	"github.com/pulpfree/gdps-fs-dwnld/model"
 	"github.com/stretchr/testify/suite"
 )
@@ -13,6 +16,7 @@ import (
 const (
 	date             = "2018-08-01"
 	defaultsFilePath = "../config/defaults.yaml"
+	fixtureDir       = "../fixture/testdata"
 	stationID        = "449d51e8-23ab-4102-8385-57eb9f31f22f"
 	timeFormat       = "2006-01-02"
 )
@@ -23,6 +27,7 @@ type UnitSuite struct {
 	client  *Client
 	cfg     *config.Config
 	request *model.Request
+	server  *gdpstest.Server
 }
 
 // SetupTest method
@@ -38,42 +43,51 @@ func (suite *UnitSuite) SetupTest() {
 	suite.NoError(err)
 	suite.IsType(new(config.Config), suite.cfg)
 
+	suite.server, err = gdpstest.NewServer(fixtureDir)
+	suite.NoError(err)
+	suite.cfg.GraphqlURI = suite.server.URL
+
 	suite.client = New(req, suite.cfg, "")
 	suite.NoError(err)
 	suite.IsType(new(Client), suite.client)
 }
 
+// TearDownTest method
+func (suite *UnitSuite) TearDownTest() {
+	suite.server.Close()
+}
+
 // TestFuelSales method
 func (suite *UnitSuite) TestFuelSales() {
-	res, err := suite.client.FuelSales()
+	res, err := suite.client.FuelSales(context.Background())
 	suite.NoError(err)
 	suite.IsType(new(model.FuelSales), res)
 }
 
 // TestFuelDelivery method
 func (suite *UnitSuite) TestFuelDelivery() {
-	res, err := suite.client.FuelDelivery()
+	res, err := suite.client.FuelDelivery(context.Background())
 	suite.NoError(err)
 	suite.IsType(new(model.FuelDelivery), res)
 }
 
 // TestOverShortMonth method
 func (suite *UnitSuite) TestOverShortMonth() {
-	res, err := suite.client.OverShortMonth()
+	res, err := suite.client.OverShortMonth(context.Background())
 	suite.NoError(err)
 	suite.IsType(new(model.OverShortMonth), res)
 }
 
 // TestOverShortAnnual method
 func (suite *UnitSuite) TestOverShortAnnual() {
-	res, err := suite.client.OverShortAnnual()
+	res, err := suite.client.OverShortAnnual(context.Background())
 	suite.NoError(err)
 	suite.IsType(new(model.OverShortAnnual), res)
 }
 
 // TestFuelSalesList method
 func (suite *UnitSuite) TestFuelSalesList() {
-	res, err := suite.client.FuelSalesList()
+	res, err := suite.client.FuelSalesList(context.Background())
 	suite.NoError(err)
 	suite.IsType(new(model.FuelSalesList), res)
 
@@ -86,6 +100,35 @@ func (suite *UnitSuite) TestFuelSalesList() {
 	suite.True(res.Report.PeriodSales[0].StationName != "")
 }
 
+// TestStationReport method
+func (suite *UnitSuite) TestStationReport() {
+	res, err := suite.client.StationReport(context.Background())
+	suite.NoError(err)
+	suite.IsType(new(model.StationReport), res)
+	suite.NotEqual("", res.FuelSales.Station.Name)
+	suite.Equal(res.FuelSales.Station, res.OverShortAnnual.Station)
+	suite.True(len(res.FuelSalesList.Report.PeriodSales) > 0)
+}
+
+// TestRetryOnServerError method
+func (suite *UnitSuite) TestRetryOnServerError() {
+	suite.server.FailNext(502)
+	res, err := suite.client.FuelDelivery(context.Background())
+	suite.NoError(err)
+	suite.IsType(new(model.FuelDelivery), res)
+	suite.Equal(2, suite.server.Requests())
+}
+
+// TestUpstreamTimeout method
+func (suite *UnitSuite) TestUpstreamTimeout() {
+	suite.client.timeout = 50 * time.Millisecond
+	suite.client.retry.MaxAttempts = 1
+	suite.server.SetLatency(100 * time.Millisecond)
+	_, err := suite.client.OverShortMonth(context.Background())
+	var tErr *TimeoutError
+	suite.True(errors.As(err, &tErr), "Expected TimeoutError")
+}
+
 // TestUnitSuite function
 func TestUnitSuite(t *testing.T) {
 	suite.Run(t, new(UnitSuite))
@@ -0,0 +1,285 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pulpfree/gdps-fs-dwnld/config"
+	"github.com/pulpfree/gdps-fs-dwnld/model"
+	"github.com/stretchr/testify/suite"
+)
+
+// ClientSuite struct
+type ClientSuite struct {
+	suite.Suite
+	server *httptest.Server
+	client *Client
+}
+
+// SetupTest method
+func (suite *ClientSuite) SetupTest() {
+	suite.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(200 * time.Millisecond):
+		}
+	}))
+	cfg := &config.Config{}
+	cfg.GraphqlURI = suite.server.URL
+	cfg.GraphqlTimeout = 20 * time.Millisecond
+
+	suite.client = New(&model.Request{Date: time.Now()}, cfg, "")
+}
+
+// TearDownTest method
+func (suite *ClientSuite) TearDownTest() {
+	suite.server.Close()
+}
+
+// TestTimeout method
+func (suite *ClientSuite) TestTimeout() {
+	_, err := suite.client.FuelDelivery(context.Background())
+	suite.Error(err)
+
+	var tErr *TimeoutError
+	suite.True(errors.As(err, &tErr), "Expected TimeoutError")
+	suite.Equal("FuelDelivery", tErr.Section)
+}
+
+// TestNoRetryOnTimeout method
+func (suite *ClientSuite) TestNoRetryOnTimeout() {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		select {
+		case <-r.Context().Done():
+		case <-time.After(200 * time.Millisecond):
+		}
+	}))
+	defer server.Close()
+
+	client := suite.retryClient(server.URL, 3)
+	client.timeout = 20 * time.Millisecond
+	_, err := client.FuelSales(context.Background())
+	var tErr *TimeoutError
+	suite.True(errors.As(err, &tErr), "Expected TimeoutError")
+	suite.Equal(int32(1), atomic.LoadInt32(&calls), "Expected no retry")
+}
+
+// TestCanceled method
+func (suite *ClientSuite) TestCanceled() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := suite.client.FuelSales(ctx)
+	suite.True(errors.Is(err, context.Canceled), "Expected context.Canceled")
+}
+
+// TestRetry method
+func (suite *ClientSuite) TestRetry() {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"data":{"station":{"id":"1","name":"Test"},"fuelDeliveryReport":{"fuelTypes":["NL"]}}}`))
+	}))
+	defer server.Close()
+
+	rpt, err := suite.retryClient(server.URL, 3).FuelDelivery(context.Background())
+	suite.NoError(err)
+	suite.Equal("Test", rpt.Station.Name)
+	suite.Equal(int32(3), calls)
+}
+
+// TestRetryExhausted method
+func (suite *ClientSuite) TestRetryExhausted() {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	_, err := suite.retryClient(server.URL, 2).FuelSales(context.Background())
+	var sErr *StatusError
+	suite.True(errors.As(err, &sErr), "Expected StatusError")
+	suite.Equal(http.StatusTooManyRequests, sErr.Code)
+	suite.Equal(int32(2), calls)
+}
+
+// TestUnauthorized method
+func (suite *ClientSuite) TestUnauthorized() {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	_, err := suite.retryClient(server.URL, 3).FuelSales(context.Background())
+	var sErr *StatusError
+	suite.True(errors.As(err, &sErr), "Expected StatusError")
+	suite.Equal(http.StatusUnauthorized, sErr.Code)
+	suite.Equal(int32(1), calls, "Expected no retry")
+}
+
+// TestStatusTransport method
+func (suite *ClientSuite) TestStatusTransport() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"data":{"station":{"id":"1","name":"Test"}}}`))
+	}))
+	defer server.Close()
+
+	var code int
+	req, err := http.NewRequestWithContext(context.WithValue(context.Background(), statusKey{}, &code), http.MethodPost, server.URL, nil)
+	suite.NoError(err)
+	res, err := (&statusTransport{next: http.DefaultTransport}).RoundTrip(req)
+	suite.NoError(err, "Expected the response without an error whatever its status")
+	res.Body.Close()
+	suite.Equal(http.StatusServiceUnavailable, code)
+
+	_, err = suite.retryClient(server.URL, 1).Station(context.Background(), "1")
+	var sErr *StatusError
+	suite.True(errors.As(err, &sErr), "Expected StatusError though the body is valid")
+}
+
+// TestStationNotFound method
+func (suite *ClientSuite) TestStationNotFound() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"errors":[{"message":"station 1234 not found"}]}`))
+	}))
+	defer server.Close()
+
+	_, err := suite.retryClient(server.URL, 1).FuelDelivery(context.Background())
+	var nfErr *NotFoundError
+	suite.True(errors.As(err, &nfErr), "Expected NotFoundError")
+	suite.Equal("FuelDelivery", nfErr.Section)
+}
+
+// TestStation method
+func (suite *ClientSuite) TestStation() {
+	responses := map[string]string{
+		"d03224a7-f1df-4863-bcaa-5c6e61af11fc": `{"data":{"station":{"id":"d03224a7-f1df-4863-bcaa-5c6e61af11fc","name":"Test"}}}`,
+		"8a1ec3b1-4d0c-4a3b-9a23-6b1f5d6e2c7a": `{"data":{"station":null}}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct{ Variables map[string]string }
+		json.NewDecoder(r.Body).Decode(&body)
+		res, ok := responses[body.Variables["stationID"]]
+		if !ok {
+			res = `{"errors":[{"message":"station not found"}]}`
+		}
+		w.Write([]byte(res))
+	}))
+	defer server.Close()
+
+	client := suite.retryClient(server.URL, 1)
+	st, err := client.Station(context.Background(), "d03224a7-f1df-4863-bcaa-5c6e61af11fc")
+	suite.NoError(err)
+	suite.Equal("Test", st.Name)
+
+	for _, id := range []string{"8a1ec3b1-4d0c-4a3b-9a23-6b1f5d6e2c7a", "4f5b7d7e-9a3c-4d5e-8f1a-2b3c4d5e6f70"} {
+		_, err = client.Station(context.Background(), id)
+		var nfErr *NotFoundError
+		suite.True(errors.As(err, &nfErr), "Expected NotFoundError for %s", id)
+		suite.Equal("Station", nfErr.Section)
+	}
+}
+
+// TestNoRetryOnGraphqlError method
+func (suite *ClientSuite) TestNoRetryOnGraphqlError() {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(`{"errors":[{"message":"invalid stationID"}]}`))
+	}))
+	defer server.Close()
+
+	_, err := suite.retryClient(server.URL, 3).OverShortMonth(context.Background())
+	suite.Error(err)
+	suite.Equal(int32(1), calls)
+}
+
+// TestStationReport method
+func (suite *ClientSuite) TestStationReport() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{
+			"station":{"id":"1","name":"Test"},
+			"fuelSales":{"fuelTypes":["DSL","NL"],"salesTotal":100},
+			"fuelDelivery":{"fuelTypes":["NL"],"deliverySummary":{"NL":50}},
+			"overShortMonth":{"fuelTypes":["NL"],"overShortSummary":{"NL":-2}},
+			"overShortAnnual":{"fuelTypes":["NL"],"year":2018},
+			"fuelSalesList":{"periodHeader":[{"yearWeek":"201831"}]}
+		}}`))
+	}))
+	defer server.Close()
+
+	rpt, err := suite.retryClient(server.URL, 1).StationReport(context.Background())
+	suite.NoError(err)
+	suite.Equal("Test", rpt.FuelSales.Station.Name)
+	suite.Equal("Test", rpt.OverShortAnnual.Station.Name)
+	suite.Equal([]string{"NL", "DSL"}, rpt.FuelSales.Report.FuelTypes)
+	suite.Equal(100.0, rpt.FuelSales.Report.SalesTotal)
+	suite.Equal(50.0, rpt.FuelDelivery.Report.DeliverySummary["NL"])
+	suite.Equal(2018, rpt.OverShortAnnual.Report.Year)
+	suite.Equal("201831", rpt.FuelSalesList.Report.PeriodHeader[0].YearWeek)
+}
+
+// TestStationReportSections method
+func (suite *ClientSuite) TestStationReportSections() {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		query = string(body)
+		w.Write([]byte(`{"data":{
+			"station":{"id":"1","name":"Test"},
+			"fuelDelivery":{"fuelTypes":["NL"],"deliverySummary":{"NL":50}}
+		}}`))
+	}))
+	defer server.Close()
+
+	client := suite.retryClient(server.URL, 1)
+	client.request.Sections = []string{model.SectionFuelDelivery}
+	rpt, err := client.StationReport(context.Background())
+	suite.NoError(err)
+
+	suite.Contains(query, "fuelDeliveryReport")
+	suite.NotContains(query, "fuelSaleMonth")
+	suite.NotContains(query, "fuelSaleListReport")
+	suite.Equal(50.0, rpt.FuelDelivery.Report.DeliverySummary["NL"])
+	suite.Nil(rpt.FuelSales)
+	suite.Nil(rpt.FuelSalesList)
+	suite.Nil(rpt.OverShortAnnual)
+}
+
+// TestBackoff method
+func (suite *ClientSuite) TestBackoff() {
+	p := RetryPolicy{MaxAttempts: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond}
+	for attempt, max := range map[int]time.Duration{1: 100, 2: 200, 3: 300, 4: 300} {
+		d := p.Backoff(attempt)
+		suite.True(d >= max*time.Millisecond/2 && d <= max*time.Millisecond, "Backoff out of range")
+	}
+}
+
+func (suite *ClientSuite) retryClient(uri string, attempts int) *Client {
+	cfg := &config.Config{}
+	cfg.GraphqlURI = uri
+	cfg.GraphqlMaxAttempts = attempts
+	cfg.GraphqlRetryBase = time.Millisecond
+	cfg.GraphqlRetryMax = 5 * time.Millisecond
+	return New(&model.Request{Date: time.Now()}, cfg, "")
+}
+
+// TestClientSuite function
+func TestClientSuite(t *testing.T) {
+	suite.Run(t, new(ClientSuite))
+}
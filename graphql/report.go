@@ -0,0 +1,190 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/machinebox/graphql"
+	"github.com/pulpfree/gdps-fs-dwnld/model"
+)
+
+// stationReportResponse struct mirrors the aliased fields in the StationReport query
+type stationReportResponse struct {
+	Station struct {
+		ID   string
+		Name string
+	}
+	FuelSales       json.RawMessage `json:"fuelSales"`
+	FuelSalesList   json.RawMessage `json:"fuelSalesList"`
+	FuelDelivery    json.RawMessage `json:"fuelDelivery"`
+	OverShortMonth  json.RawMessage `json:"overShortMonth"`
+	OverShortAnnual json.RawMessage `json:"overShortAnnual"`
+}
+
+// stationReportQueries holds each aliased report query and the sections that need it
+var stationReportQueries = []struct {
+	sections []string
+	query    string
+}{
+	{[]string{model.SectionFuelSales}, `
+      fuelSales: fuelSaleMonth(date: $date, stationID: $stationID) {
+        fuelTypes
+        stationSales {
+          date
+          sales
+        }
+        salesSummary
+        salesTotal
+      }`},
+	{[]string{model.SectionFuelDelivery}, `
+      fuelDelivery: fuelDeliveryReport(date: $date, stationID: $stationID) {
+        fuelTypes
+        deliveries {
+          data
+          date
+        }
+        deliverySummary
+      }`},
+	{[]string{model.SectionOverShortMonth}, `
+      overShortMonth: dipOSMonthReport(date: $date, stationID: $stationID) {
+        stationID
+        fuelTypes
+        period
+        overShort {
+          date
+          data
+        }
+        overShortSummary
+      }`},
+	{[]string{model.SectionOverShortAnnual}, `
+      overShortAnnual: dipOSAnnualReport(date: $date, stationID: $stationID) {
+        fuelTypes
+        year
+        months
+        summary
+      }`},
+	{[]string{model.SectionFuelSalesNL, model.SectionFuelSalesDSL}, `
+      fuelSalesList: fuelSaleListReport(date: $date) {
+        periodHeader {
+          yearWeek
+          startDate
+          endDate
+          week
+        }
+        periodSales {
+          fuelPrices
+          periods {
+            dates
+            fuelSales {
+              NL
+              DSL
+            }
+          }
+          stationID
+          stationName
+          stationTotal {
+            NL
+            DSL
+          }
+        }
+        periodTotals {
+          period
+          NL
+          DSL
+        }
+        totalsByFuel {
+          NL
+          DSL
+        }
+      }`},
+}
+
+// StationReport method fetches the station and the requested report sections
+// in a single query. Sections not requested are left nil.
+func (c *Client) StationReport(ctx context.Context) (rpt *model.StationReport, err error) {
+
+	var query strings.Builder
+	query.WriteString(`
+    query StationReport($date: String!, $stationID: String!) {
+      station(stationID: $stationID) {
+        id
+        name
+      }`)
+	for _, q := range stationReportQueries {
+		if c.wantsAny(q.sections) {
+			query.WriteString(q.query)
+		}
+	}
+	query.WriteString(`
+    }
+  `)
+
+	req := graphql.NewRequest(query.String())
+	req.Var("date", formattedDate(c.request.Date))
+	req.Var("stationID", c.request.StationID)
+
+	var res stationReportResponse
+	err = c.run(ctx, "StationReport", req, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	rpt = new(model.StationReport)
+	date := c.request.Date
+
+	if c.request.HasSection(model.SectionFuelSales) {
+		rpt.FuelSales = &model.FuelSales{Date: date, Station: res.Station}
+		if err = decodeSection(res.FuelSales, &rpt.FuelSales.Report); err != nil {
+			return nil, err
+		}
+		rpt.FuelSales.Report.FuelTypes = model.SortFuelTypes(rpt.FuelSales.Report.FuelTypes)
+	}
+	if c.wantsAny([]string{model.SectionFuelSalesNL, model.SectionFuelSalesDSL}) {
+		rpt.FuelSalesList = &model.FuelSalesList{Date: date}
+		if err = decodeSection(res.FuelSalesList, &rpt.FuelSalesList.Report); err != nil {
+			return nil, err
+		}
+	}
+	if c.request.HasSection(model.SectionFuelDelivery) {
+		rpt.FuelDelivery = &model.FuelDelivery{Date: date, Station: res.Station}
+		if err = decodeSection(res.FuelDelivery, &rpt.FuelDelivery.Report); err != nil {
+			return nil, err
+		}
+		rpt.FuelDelivery.Report.FuelTypes = model.SortFuelTypes(rpt.FuelDelivery.Report.FuelTypes)
+	}
+	if c.request.HasSection(model.SectionOverShortMonth) {
+		rpt.OverShortMonth = &model.OverShortMonth{Date: date, Station: res.Station}
+		if err = decodeSection(res.OverShortMonth, &rpt.OverShortMonth.Report); err != nil {
+			return nil, err
+		}
+		rpt.OverShortMonth.Report.FuelTypes = model.SortFuelTypes(rpt.OverShortMonth.Report.FuelTypes)
+	}
+	if c.request.HasSection(model.SectionOverShortAnnual) {
+		rpt.OverShortAnnual = &model.OverShortAnnual{Date: date, Station: res.Station}
+		if err = decodeSection(res.OverShortAnnual, &rpt.OverShortAnnual.Report); err != nil {
+			return nil, err
+		}
+		rpt.OverShortAnnual.Report.FuelTypes = model.SortFuelTypes(rpt.OverShortAnnual.Report.FuelTypes)
+	}
+
+	return rpt, err
+}
+
+// wantsAny method reports whether the request includes any of sections
+func (c *Client) wantsAny(sections []string) bool {
+	for _, s := range sections {
+		if c.request.HasSection(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeSection function unmarshals an aliased section into its report struct
+func decodeSection(data json.RawMessage, v interface{}) error {
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,124 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pulpfree/gdps-fs-dwnld/config"
+)
+
+// Retry defaults used when config values are not set
+const (
+	defaultMaxAttempts = 1
+	defaultRetryBase   = 200 * time.Millisecond
+	defaultRetryMax    = 2 * time.Second
+)
+
+// RetryPolicy struct
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// NewRetryPolicy function
+func NewRetryPolicy(cfg *config.Config) RetryPolicy {
+
+	p := RetryPolicy{
+		MaxAttempts: cfg.GraphqlMaxAttempts,
+		BaseDelay:   cfg.GraphqlRetryBase,
+		MaxDelay:    cfg.GraphqlRetryMax,
+	}
+	if p.MaxAttempts < 1 {
+		p.MaxAttempts = defaultMaxAttempts
+	}
+	if p.BaseDelay <= 0 {
+		p.BaseDelay = defaultRetryBase
+	}
+	if p.MaxDelay < p.BaseDelay {
+		p.MaxDelay = defaultRetryMax
+	}
+	return p
+}
+
+// Backoff method returns the wait before the next attempt. The delay doubles
+// with each attempt up to MaxDelay, and is jittered between half and the full value.
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+
+	d := p.BaseDelay
+	for i := 1; i < attempt && d < p.MaxDelay; i++ {
+		d *= 2
+	}
+	if d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	half := int64(d / 2)
+	return time.Duration(half + rand.Int63n(half+1))
+}
+
+// wait method sleeps for the attempt's backoff, returning early if ctx is done
+func (p RetryPolicy) wait(ctx context.Context, attempt int) error {
+	t := time.NewTimer(p.Backoff(attempt))
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// retryable function reports whether err is a transport failure or a 5xx/429
+// response. Timed out queries aren't retried, another full timeout per attempt
+// would outlast the caller's own deadline.
+func retryable(err error) bool {
+
+	var tErr *TimeoutError
+	if errors.As(err, &tErr) {
+		return false
+	}
+
+	var sErr *StatusError
+	if errors.As(err, &sErr) {
+		return sErr.Code >= 500 || sErr.Code == http.StatusTooManyRequests
+	}
+
+	var uErr *url.Error
+	return errors.As(err, &uErr)
+}
+
+// statusKey is the context key of the status code filled in by statusTransport
+type statusKey struct{}
+
+// statusTransport struct records the status code of each response in the
+// *int held by the request context under statusKey. The response itself is
+// passed on untouched as http.RoundTripper requires.
+type statusTransport struct {
+	next http.RoundTripper
+}
+
+// RoundTrip method
+func (t *statusTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+
+	res, err := t.next.RoundTrip(req)
+	if code, ok := req.Context().Value(statusKey{}).(*int); ok && res != nil {
+		*code = res.StatusCode
+	}
+	return res, err
+}
+
+// statusError function returns a StatusError for server error, rate limit and
+// authorization status codes so that they can be told apart from GraphQL errors
+func statusError(code int) error {
+	switch {
+	case code >= 500, code == http.StatusTooManyRequests,
+		code == http.StatusUnauthorized, code == http.StatusForbidden:
+		return &StatusError{Code: code}
+	}
+	return nil
+}
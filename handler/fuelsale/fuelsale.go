@@ -1,7 +1,10 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	pres "github.com/pulpfree/lambda-go-proxy-response"
@@ -9,13 +12,23 @@ import (
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/pulpfree/gdps-fs-dwnld/apierr"
+	"github.com/pulpfree/gdps-fs-dwnld/authz"
+	"github.com/pulpfree/gdps-fs-dwnld/awsservices"
 	"github.com/pulpfree/gdps-fs-dwnld/config"
 	"github.com/pulpfree/gdps-fs-dwnld/fuelsale"
+	"github.com/pulpfree/gdps-fs-dwnld/graphql"
+	"github.com/pulpfree/gdps-fs-dwnld/job"
 	"github.com/pulpfree/gdps-fs-dwnld/model"
+	"github.com/pulpfree/gdps-fs-dwnld/storage"
 	"github.com/pulpfree/gdps-fs-dwnld/validate"
 )
 
-var cfg *config.Config
+var (
+	cfg      *config.Config
+	policy   *authz.Policy                // nil when AuthPolicyFile isn't set
+	stations = validate.NewStationCache() // kept while the lambda is warm
+)
 
 func init() {
 	cfg = &config.Config{}
@@ -23,6 +36,12 @@ func init() {
 	if err != nil {
 		log.Fatal(err)
 	}
+	if cfg.AuthPolicyFile != "" {
+		policy, err = authz.Load(cfg.AuthPolicyFile, cfg.StationGroups)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
 }
 
 // SignedURL struct
@@ -31,7 +50,7 @@ type SignedURL struct {
 }
 
 // HandleRequest function
-func HandleRequest(req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+func HandleRequest(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 
 	hdrs := make(map[string]string)
 	hdrs["Content-Type"] = "application/json"
@@ -45,6 +64,11 @@ func HandleRequest(req events.APIGatewayProxyRequest) (events.APIGatewayProxyRes
 
 	t := time.Now()
 
+	// Report job status
+	if id := req.PathParameters["id"]; req.HTTPMethod == "GET" && id != "" {
+		return jobStatus(ctx, id, authz.Claims(req.RequestContext), hdrs), nil
+	}
+
 	// If this is a ping test, intercept and return
 	if req.HTTPMethod == "GET" {
 		log.Info("Ping test in handleRequest")
@@ -57,36 +81,81 @@ func HandleRequest(req events.APIGatewayProxyRequest) (events.APIGatewayProxyRes
 	}
 
 	// Set and validate request params
-	var r *model.RequestInput
-	json.Unmarshal([]byte(req.Body), &r)
-	reqVars, err := validate.RequestInput(r)
+	r, err := validate.Decode(strings.NewReader(req.Body))
+	var reqVars *model.Request
+	if err == nil {
+		reqVars, err = validate.RequestInput(r)
+	}
+	if err == nil {
+		err = validate.StationGroup(reqVars, cfg.StationGroups)
+	}
 	if err != nil {
-		return pres.ProxyRes(pres.Response{
-			Timestamp: t.Unix(),
-		}, hdrs, err), nil
+		return apierr.ProxyRes(apierr.Wrap(apierr.CodeValidation, err), hdrs), nil
+	}
+
+	// Users may only see the stations their groups allow
+	if policy != nil {
+		if err = policy.Authorize(authz.Claims(req.RequestContext), reqVars); err != nil {
+			return apierr.ProxyRes(err, hdrs), nil
+		}
+	}
+
+	// Unknown stations are refused before any report work. Jobs check them in
+	// the job function so that the request returns straight away.
+	if cfg.StationLookup && !reqVars.Async {
+		client := graphql.New(reqVars, cfg, req.Headers["Authorization"])
+		if err = stations.Stations(ctx, reqVars, client, cfg.FetchConcurrency); err != nil {
+			return apierr.ProxyRes(err, hdrs), nil
+		}
 	}
 
 	// Process request
-	report, err := fuelsale.New(reqVars, cfg, req.Headers["Authorization"])
+	store, err := storage.New(cfg)
 	if err != nil {
-		return pres.ProxyRes(pres.Response{
-			Timestamp: t.Unix(),
-		}, hdrs, err), nil
+		return apierr.ProxyRes(apierr.Wrap(apierr.CodeStorage, err), hdrs), nil
 	}
 
-	// var url string
-	err = report.Create()
+	report, err := fuelsale.ForRequest(reqVars, cfg, req.Headers["Authorization"], store)
 	if err != nil {
+		return apierr.ProxyRes(err, hdrs), nil
+	}
+
+	// Closed months may already have a stored copy
+	url, cached, err := report.CachedURL(ctx)
+	if err != nil {
+		return apierr.ProxyRes(apierr.Wrap(apierr.CodeStorage, err), hdrs), nil
+	}
+	if cached {
 		return pres.ProxyRes(pres.Response{
+			Code:      201,
+			Data:      SignedURL{URL: url},
+			Status:    "success",
 			Timestamp: t.Unix(),
-		}, hdrs, err), nil
+		}, hdrs, nil), nil
 	}
 
-	url, err := report.CreateSignedURL()
-	if err != nil {
+	// Long reports are created in the background and polled for
+	if reqVars.Async {
+		j, err := startJob(ctx, reqVars, r, req.Headers["Authorization"])
+		if err != nil {
+			return apierr.ProxyRes(err, hdrs), nil
+		}
 		return pres.ProxyRes(pres.Response{
+			Code:      202,
+			Data:      j,
+			Status:    "success",
 			Timestamp: t.Unix(),
-		}, hdrs, err), nil
+		}, hdrs, nil), nil
+	}
+
+	err = report.Create(ctx)
+	if err != nil {
+		return apierr.ProxyRes(err, hdrs), nil
+	}
+
+	url, err = report.CreateSignedURL(ctx)
+	if err != nil {
+		return apierr.ProxyRes(apierr.Wrap(apierr.CodeStorage, err), hdrs), nil
 	}
 	log.Infof("signed url created %s", url)
 
@@ -98,6 +167,91 @@ func HandleRequest(req events.APIGatewayProxyRequest) (events.APIGatewayProxyRes
 	}, hdrs, nil), nil
 }
 
+// startJob function records a queued job for the request and invokes the
+// job function to create the report
+func startJob(ctx context.Context, reqVars *model.Request, input *model.RequestInput, authToken string) (*job.Job, error) {
+
+	if cfg.JobFunction == "" {
+		return nil, errors.New("Asynchronous reports are not available, missing JobFunction")
+	}
+	store, err := job.NewStore(cfg)
+	if err != nil {
+		return nil, apierr.Wrap(apierr.CodeStorage, err)
+	}
+	invoker, err := awsservices.NewLambda(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	j, err := job.New(reqVars, cfg.JobTTL)
+	if err != nil {
+		return nil, err
+	}
+	if err = store.Put(ctx, j); err != nil {
+		return nil, apierr.Wrap(apierr.CodeStorage, err)
+	}
+
+	err = invoker.InvokeAsync(ctx, cfg.JobFunction, job.Event{
+		JobID:         j.ID,
+		Request:       *input,
+		Authorization: authToken,
+	})
+	if err != nil {
+		err = apierr.Wrap(apierr.CodeUpstream, err)
+		// A job that never started mustn't be left queued
+		if ferr := job.Fail(ctx, store, j, err); ferr != nil {
+			log.Errorf("Error saving job %s: %s", j.ID, ferr)
+		}
+		return nil, err
+	}
+	log.Infof("Queued report job %s", j.ID)
+	return j, err
+}
+
+// jobStatus function responds with the job, or 404 when there is no such job.
+// The caller must be allowed the job's stations as when it was requested.
+// Links to finished reports are signed for each request as they expire long
+// before the job.
+func jobStatus(ctx context.Context, id string, claims map[string]interface{}, hdrs map[string]string) events.APIGatewayProxyResponse {
+
+	t := time.Now()
+	store, err := job.NewStore(cfg)
+	if err != nil {
+		return apierr.ProxyRes(apierr.Wrap(apierr.CodeStorage, err), hdrs)
+	}
+
+	j, err := store.Get(ctx, id)
+	if err == job.ErrNotFound {
+		return apierr.ProxyRes(apierr.Wrap(apierr.CodeJobNotFound, fmt.Errorf("Job not found: %s", id)), hdrs)
+	}
+	if err != nil {
+		return apierr.ProxyRes(apierr.Wrap(apierr.CodeStorage, err), hdrs)
+	}
+
+	if policy != nil {
+		if err = policy.Authorize(claims, &model.Request{StationIDs: j.Stations}); err != nil {
+			return apierr.ProxyRes(err, hdrs)
+		}
+	}
+
+	if j.Status == job.StatusDone && j.Key != "" {
+		files, err := storage.New(cfg)
+		if err == nil {
+			j.URL, err = files.SignedURL(ctx, j.Key, fuelsale.URLExpiry(j.URLExpiry, cfg))
+		}
+		if err != nil {
+			return apierr.ProxyRes(apierr.Wrap(apierr.CodeStorage, err), hdrs)
+		}
+	}
+
+	return pres.ProxyRes(pres.Response{
+		Code:      200,
+		Data:      j,
+		Status:    "success",
+		Timestamp: t.Unix(),
+	}, hdrs, nil)
+}
+
 func main() {
 	lambda.Start(HandleRequest)
 }
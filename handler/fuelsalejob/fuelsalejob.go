@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/pulpfree/gdps-fs-dwnld/apierr"
+	"github.com/pulpfree/gdps-fs-dwnld/config"
+	"github.com/pulpfree/gdps-fs-dwnld/fuelsale"
+	"github.com/pulpfree/gdps-fs-dwnld/graphql"
+	"github.com/pulpfree/gdps-fs-dwnld/job"
+	"github.com/pulpfree/gdps-fs-dwnld/storage"
+	"github.com/pulpfree/gdps-fs-dwnld/validate"
+)
+
+var (
+	cfg      *config.Config
+	stations = validate.NewStationCache() // kept while the lambda is warm
+)
+
+func init() {
+	cfg = &config.Config{}
+	err := cfg.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// HandleJob function creates the report of a job queued by the fuelsale
+// handler. Report errors are recorded on the job rather than returned, as
+// a returned error would have the job run again.
+func HandleJob(ctx context.Context, ev job.Event) error {
+
+	store, err := job.NewStore(cfg)
+	if err != nil {
+		return err
+	}
+
+	err = job.Run(ctx, store, ev.JobID, func(ctx context.Context, progress func(section string)) (string, error) {
+		return createReport(ctx, ev, progress)
+	})
+	if err == job.ErrNotFound {
+		log.Errorf("Job %s not found", ev.JobID)
+		return nil
+	}
+	if err != nil {
+		log.Errorf("Job %s failed: %s", ev.JobID, err)
+	}
+	return nil
+}
+
+// createReport function creates and stores the requested report and returns its storage key
+func createReport(ctx context.Context, ev job.Event, progress func(section string)) (key string, err error) {
+
+	reqVars, err := validate.RequestInput(&ev.Request)
+	if err == nil {
+		err = validate.StationGroup(reqVars, cfg.StationGroups)
+	}
+	if err != nil {
+		return "", apierr.Wrap(apierr.CodeValidation, err)
+	}
+	if cfg.StationLookup {
+		client := graphql.New(reqVars, cfg, ev.Authorization)
+		if err = stations.Stations(ctx, reqVars, client, cfg.FetchConcurrency); err != nil {
+			return "", err
+		}
+	}
+
+	store, err := storage.New(cfg)
+	if err != nil {
+		return "", apierr.Wrap(apierr.CodeStorage, err)
+	}
+
+	report, err := fuelsale.ForRequest(reqVars, cfg, ev.Authorization, store)
+	if err != nil {
+		return "", err
+	}
+	report.SetProgress(progress)
+
+	if err = report.Create(ctx); err != nil {
+		return "", err
+	}
+	key, err = report.Save(ctx)
+	return key, apierr.Wrap(apierr.CodeStorage, err)
+}
+
+func main() {
+	lambda.Start(HandleJob)
+}
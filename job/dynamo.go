@@ -0,0 +1,71 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/pulpfree/gdps-fs-dwnld/apierr"
+	"github.com/pulpfree/gdps-fs-dwnld/awsservices"
+	"github.com/pulpfree/gdps-fs-dwnld/config"
+)
+
+// dynamoKey is the hash key of the jobs table
+const dynamoKey = "id"
+
+// Dynamo struct stores jobs in the configured DynamoDB table. The table is
+// keyed by the string attribute id and expires items by the expires attribute.
+type Dynamo struct {
+	serv  *awsservices.DynamoService
+	table string
+}
+
+// dynamoItem struct is the stored form of a Job
+type dynamoItem struct {
+	ID        string        `dynamodbav:"id"`
+	Status    Status        `dynamodbav:"status"`
+	Sections  []Section     `dynamodbav:"sections"`
+	Stations  []string      `dynamodbav:"stations"`
+	URL       string        `dynamodbav:"-"` // signed again from key, the url expires first
+	Key       string        `dynamodbav:"key,omitempty"`
+	URLExpiry time.Duration `dynamodbav:"urlExpiry,omitempty"`
+	Error     string        `dynamodbav:"error,omitempty"`
+	ErrorCode apierr.Code   `dynamodbav:"errorCode,omitempty"`
+	CreatedAt time.Time     `dynamodbav:"createdAt"`
+	UpdatedAt time.Time     `dynamodbav:"updatedAt"`
+	Expires   int64         `dynamodbav:"expires"`
+}
+
+// NewDynamo function
+func NewDynamo(cfg *config.Config) (d *Dynamo, err error) {
+
+	if cfg.JobTable == "" {
+		return nil, errors.New("Missing JobTable")
+	}
+	serv, err := awsservices.NewDynamo(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &Dynamo{serv: serv, table: cfg.JobTable}, err
+}
+
+// Put method
+func (d *Dynamo) Put(ctx context.Context, j *Job) error {
+	return d.serv.PutItem(ctx, d.table, dynamoItem(*j))
+}
+
+// Get method returns the job, DynamoDB may keep items for a while after
+// they expire so these are treated as missing
+func (d *Dynamo) Get(ctx context.Context, id string) (*Job, error) {
+
+	var item dynamoItem
+	found, err := d.serv.GetItem(ctx, d.table, dynamoKey, id, &item)
+	if err != nil {
+		return nil, err
+	}
+	if !found || (item.Expires > 0 && now().Unix() > item.Expires) {
+		return nil, ErrNotFound
+	}
+	j := Job(item)
+	return &j, nil
+}
@@ -0,0 +1,196 @@
+package job
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pulpfree/gdps-fs-dwnld/apierr"
+	"github.com/pulpfree/gdps-fs-dwnld/config"
+	"github.com/pulpfree/gdps-fs-dwnld/model"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Store names used by the JobStore config value
+const (
+	StoreDynamo = "dynamodb"
+	StoreMemory = "memory"
+)
+
+const defaultTTL = 24 * time.Hour
+
+// Status string
+type Status string
+
+// Status type constants
+const (
+	StatusQueued  Status = "queued"
+	StatusRunning Status = "running"
+	StatusDone    Status = "done"
+	StatusFailed  Status = "failed"
+)
+
+// ErrNotFound is returned by Get when there is no job with the ID
+var ErrNotFound = errors.New("job: not found")
+
+// now is replaced in tests
+var now = time.Now
+
+// Job struct records the progress of a report created in the background
+type Job struct {
+	ID        string        `json:"id"`
+	Status    Status        `json:"status"`
+	Sections  []Section     `json:"sections"`
+	Stations  []string      `json:"-"`                   // requested station IDs, checked on each status request
+	URL       string        `json:"url,omitempty"`       // signed from Key for each status request
+	Key       string        `json:"-"`                   // storage key of the report once done
+	URLExpiry time.Duration `json:"-"`                   // requested url lifetime, see model.Request
+	Error     string        `json:"error,omitempty"`     // set when failed
+	ErrorCode apierr.Code   `json:"errorCode,omitempty"` // set when failed, see apierr.Code
+	CreatedAt time.Time     `json:"createdAt"`
+	UpdatedAt time.Time     `json:"updatedAt"`
+	Expires   int64         `json:"-"` // unix time the record may be removed
+}
+
+// Section struct holds the progress of one report section
+type Section struct {
+	Name   string `json:"name"`
+	Status Status `json:"status"` // queued until written, then done
+}
+
+// Event struct is the payload of the function running a job. The request
+// is validated again and the authorization passed on to the GDPS API.
+type Event struct {
+	JobID         string             `json:"jobID"`
+	Request       model.RequestInput `json:"request"`
+	Authorization string             `json:"authorization"`
+}
+
+// Store interface is implemented by each job store
+type Store interface {
+	Put(ctx context.Context, j *Job) error
+	Get(ctx context.Context, id string) (*Job, error)
+}
+
+// memory is the store shared by every NewStore call in the process
+var memory = NewMemory()
+
+// NewStore function returns the store selected by cfg.JobStore. The memory
+// store is refused in Lambda as jobs run in another function.
+func NewStore(cfg *config.Config) (Store, error) {
+
+	switch cfg.JobStore {
+	case "", StoreDynamo:
+		return NewDynamo(cfg)
+	case StoreMemory:
+		if os.Getenv("AWS_LAMBDA_FUNCTION_NAME") != "" {
+			return nil, fmt.Errorf("JobStore %s can't be used in Lambda, use %s", StoreMemory, StoreDynamo)
+		}
+		return memory, nil
+	}
+	return nil, fmt.Errorf("Invalid JobStore: %s", cfg.JobStore)
+}
+
+// New function returns a queued job for the sections of req, kept for ttl
+func New(req *model.Request, ttl time.Duration) (j *Job, err error) {
+
+	id, err := newID()
+	if err != nil {
+		return nil, err
+	}
+	if ttl <= 0 {
+		ttl = defaultTTL
+	}
+
+	t := now().UTC()
+	j = &Job{
+		ID:        id,
+		Status:    StatusQueued,
+		Stations:  req.StationList(),
+		URLExpiry: req.URLExpiry,
+		CreatedAt: t,
+		UpdatedAt: t,
+		Expires:   t.Add(ttl).Unix(),
+	}
+	for _, name := range req.SectionList() {
+		j.Sections = append(j.Sections, Section{Name: name, Status: StatusQueued})
+	}
+	return j, err
+}
+
+// SectionDone method marks the named section as written
+func (j *Job) SectionDone(name string) {
+	for i := range j.Sections {
+		if j.Sections[i].Name == name {
+			j.Sections[i].Status = StatusDone
+		}
+	}
+}
+
+// Run function runs work for the job with ID id and records its progress and
+// outcome in store. work reports each section as it is written and returns
+// the storage key of the report. The error returned is that of work.
+func Run(ctx context.Context, store Store, id string, work func(ctx context.Context, progress func(section string)) (string, error)) (err error) {
+
+	j, err := store.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	j.Status = StatusRunning
+	if err = put(ctx, store, j); err != nil {
+		return err
+	}
+
+	key, err := work(ctx, func(section string) {
+		j.SectionDone(section)
+		// Progress is informational, a failed update shouldn't fail the report
+		if err := put(ctx, store, j); err != nil {
+			log.Errorf("Error updating job %s progress: %s", j.ID, err)
+		}
+	})
+	if err != nil {
+		if perr := Fail(ctx, store, j, err); perr != nil {
+			log.Errorf("Error saving job %s: %s", j.ID, perr)
+		}
+		return err
+	}
+
+	j.Status = StatusDone
+	j.Key = key
+	// The job must end up done even when ctx has ended
+	if err = put(context.Background(), store, j); err != nil {
+		log.Errorf("Error saving job %s: %s", j.ID, err)
+	}
+	return err
+}
+
+// Fail function records j as failed with cause, see apierr.From
+func Fail(ctx context.Context, store Store, j *Job, cause error) error {
+	j.Status = StatusFailed
+	j.Error = cause.Error()
+	j.ErrorCode = apierr.From(cause).Code
+	// The job must end up failed even when ctx has ended
+	return put(context.Background(), store, j)
+}
+
+// put function saves j with the time of the update
+func put(ctx context.Context, store Store, j *Job) error {
+	j.UpdatedAt = now().UTC()
+	return store.Put(ctx, j)
+}
+
+// newID function returns a random version 4 UUID
+func newID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
@@ -0,0 +1,153 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"os"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/pulpfree/gdps-fs-dwnld/apierr"
+	"github.com/pulpfree/gdps-fs-dwnld/config"
+	"github.com/pulpfree/gdps-fs-dwnld/model"
+	"github.com/stretchr/testify/suite"
+)
+
+// UnitSuite struct
+type UnitSuite struct {
+	suite.Suite
+	store *Memory
+	req   *model.Request
+}
+
+// SetupTest method
+func (suite *UnitSuite) SetupTest() {
+	suite.store = NewMemory()
+	suite.req = &model.Request{
+		Sections:   []string{model.SectionFuelSales, model.SectionFuelDelivery},
+		StationIDs: []string{"d03224a7-f1df-4863-bcaa-5c6e61af11fc", "449d51e8-7e30-4ea2-8ba0-4f6bd3fbbf1e"},
+		URLExpiry:  time.Hour,
+	}
+}
+
+// TearDownTest method
+func (suite *UnitSuite) TearDownTest() {
+	now = time.Now
+}
+
+// TestNew method
+func (suite *UnitSuite) TestNew() {
+	j, err := New(suite.req, time.Hour)
+	suite.NoError(err)
+	suite.Regexp(regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`), j.ID)
+	suite.Equal(StatusQueued, j.Status)
+	suite.Equal([]Section{
+		{Name: model.SectionFuelSales, Status: StatusQueued},
+		{Name: model.SectionFuelDelivery, Status: StatusQueued},
+	}, j.Sections)
+	suite.Equal(j.CreatedAt.Add(time.Hour).Unix(), j.Expires)
+	suite.Equal(time.Hour, j.URLExpiry)
+	suite.Equal(suite.req.StationIDs, j.Stations)
+
+	other, err := New(&model.Request{}, 0)
+	suite.NoError(err)
+	suite.NotEqual(j.ID, other.ID)
+	suite.Len(other.Sections, len(model.Sections), "Expected every section by default")
+	suite.Equal(other.CreatedAt.Add(defaultTTL).Unix(), other.Expires)
+}
+
+// TestRun method
+func (suite *UnitSuite) TestRun() {
+	ctx := context.Background()
+	j, err := New(suite.req, time.Hour)
+	suite.NoError(err)
+	suite.NoError(suite.store.Put(ctx, j))
+
+	err = Run(ctx, suite.store, j.ID, func(ctx context.Context, progress func(string)) (string, error) {
+		running, err := suite.store.Get(ctx, j.ID)
+		suite.NoError(err)
+		suite.Equal(StatusRunning, running.Status)
+
+		progress(model.SectionFuelSales)
+		partial, err := suite.store.Get(ctx, j.ID)
+		suite.NoError(err)
+		suite.Equal(StatusDone, partial.Sections[0].Status)
+		suite.Equal(StatusQueued, partial.Sections[1].Status)
+
+		progress(model.SectionFuelDelivery)
+		return "reports/report.xlsx", nil
+	})
+	suite.NoError(err)
+
+	done, err := suite.store.Get(ctx, j.ID)
+	suite.NoError(err)
+	suite.Equal(StatusDone, done.Status)
+	suite.Equal("reports/report.xlsx", done.Key)
+	suite.Equal(StatusDone, done.Sections[1].Status)
+}
+
+// TestRunFailed method
+func (suite *UnitSuite) TestRunFailed() {
+	ctx := context.Background()
+	j, err := New(suite.req, time.Hour)
+	suite.NoError(err)
+	suite.NoError(suite.store.Put(ctx, j))
+
+	err = Run(ctx, suite.store, j.ID, func(ctx context.Context, progress func(string)) (string, error) {
+		return "", errors.New("Missing station")
+	})
+	suite.EqualError(err, "Missing station")
+
+	failed, err := suite.store.Get(ctx, j.ID)
+	suite.NoError(err)
+	suite.Equal(StatusFailed, failed.Status)
+	suite.Equal("Missing station", failed.Error)
+	suite.Equal(apierr.CodeInternal, failed.ErrorCode)
+	suite.Empty(failed.Key)
+
+	err = Run(ctx, suite.store, "missing", func(ctx context.Context, progress func(string)) (string, error) {
+		suite.Fail("Expected work not to run without a job")
+		return "", nil
+	})
+	suite.Equal(ErrNotFound, err)
+}
+
+// TestFail method
+func (suite *UnitSuite) TestFail() {
+	ctx := context.Background()
+	j, err := New(suite.req, time.Hour)
+	suite.NoError(err)
+	suite.NoError(suite.store.Put(ctx, j))
+
+	suite.NoError(Fail(ctx, suite.store, j, apierr.Wrap(apierr.CodeUpstream, errors.New("Invoke failed"))))
+	failed, err := suite.store.Get(ctx, j.ID)
+	suite.NoError(err)
+	suite.Equal(StatusFailed, failed.Status)
+	suite.Equal(apierr.CodeUpstream, failed.ErrorCode)
+}
+
+// TestNewStore method
+func (suite *UnitSuite) TestNewStore() {
+	cfg := &config.Config{}
+	cfg.JobStore = StoreMemory
+	a, err := NewStore(cfg)
+	suite.NoError(err)
+	b, err := NewStore(cfg)
+	suite.NoError(err)
+	suite.True(a == b, "Expected one memory store per process")
+
+	os.Setenv("AWS_LAMBDA_FUNCTION_NAME", "gdps-fs-dwnld")
+	defer os.Unsetenv("AWS_LAMBDA_FUNCTION_NAME")
+	_, err = NewStore(cfg)
+	suite.Error(err, "Expected the memory store to be refused in Lambda")
+
+	cfg.JobStore = "file"
+	_, err = NewStore(cfg)
+	suite.Error(err)
+}
+
+// TestUnitSuite function
+func TestUnitSuite(t *testing.T) {
+	suite.Run(t, new(UnitSuite))
+}
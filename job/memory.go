@@ -0,0 +1,46 @@
+package job
+
+import (
+	"context"
+	"sync"
+)
+
+// Memory struct keeps jobs in memory. Jobs are only visible to the process
+// that created them so it is only for the command line and tests.
+type Memory struct {
+	mu   sync.Mutex
+	jobs map[string]Job
+}
+
+// NewMemory function
+func NewMemory() *Memory {
+	return &Memory{jobs: make(map[string]Job)}
+}
+
+// Put method
+func (m *Memory) Put(ctx context.Context, j *Job) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.jobs[j.ID] = copyJob(j)
+	return nil
+}
+
+// Get method
+func (m *Memory) Get(ctx context.Context, id string) (*Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	j, ok := m.jobs[id]
+	if !ok || (j.Expires > 0 && now().Unix() > j.Expires) {
+		return nil, ErrNotFound
+	}
+	res := copyJob(&j)
+	return &res, nil
+}
+
+// copyJob function copies j so that callers can't change a stored job
+func copyJob(j *Job) Job {
+	res := *j
+	res.Sections = append([]Section(nil), j.Sections...)
+	res.Stations = append([]string(nil), j.Stations...)
+	return res
+}
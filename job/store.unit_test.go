@@ -0,0 +1,128 @@
+package job
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/pulpfree/gdps-fs-dwnld/config"
+	"github.com/pulpfree/gdps-fs-dwnld/gdpstest"
+	"github.com/pulpfree/gdps-fs-dwnld/model"
+	"github.com/stretchr/testify/suite"
+)
+
+const jobTable = "gdps-report-jobs"
+
+// StoreSuite struct runs the same tests against each Store
+type StoreSuite struct {
+	suite.Suite
+	newStore func() Store
+	store    Store
+}
+
+// SetupTest method
+func (suite *StoreSuite) SetupTest() {
+	suite.store = suite.newStore()
+}
+
+// TearDownTest method
+func (suite *StoreSuite) TearDownTest() {
+	now = time.Now
+}
+
+// TestPutGet method
+func (suite *StoreSuite) TestPutGet() {
+	ctx := context.Background()
+	j, err := New(&model.Request{StationID: "d03224a7-f1df-4863-bcaa-5c6e61af11fc", Sections: []string{model.SectionOverShortMonth}, URLExpiry: time.Hour}, time.Hour)
+	suite.NoError(err)
+	suite.NoError(suite.store.Put(ctx, j))
+
+	got, err := suite.store.Get(ctx, j.ID)
+	suite.NoError(err)
+	suite.Equal(j.ID, got.ID)
+	suite.Equal(StatusQueued, got.Status)
+	suite.Equal(j.Sections, got.Sections)
+	suite.Equal(j.Stations, got.Stations)
+	suite.True(j.CreatedAt.Equal(got.CreatedAt))
+	suite.Equal(j.Expires, got.Expires)
+
+	// Changes are only kept once put
+	got.SectionDone(model.SectionOverShortMonth)
+	again, err := suite.store.Get(ctx, j.ID)
+	suite.NoError(err)
+	suite.Equal(StatusQueued, again.Sections[0].Status)
+
+	got.Status = StatusDone
+	got.Key = "reports/report.xlsx"
+	got.URL = "https://example.com/report.xlsx"
+	suite.NoError(suite.store.Put(ctx, got))
+	again, err = suite.store.Get(ctx, j.ID)
+	suite.NoError(err)
+	suite.Equal(StatusDone, again.Status)
+	suite.Equal(StatusDone, again.Sections[0].Status)
+	suite.Equal(got.Key, again.Key)
+	suite.Equal(j.URLExpiry, again.URLExpiry)
+}
+
+// TestNotFound method
+func (suite *StoreSuite) TestNotFound() {
+	ctx := context.Background()
+	_, err := suite.store.Get(ctx, "4b1e6fd0-51ad-4b07-8d8a-6f3cbb0d3a5e")
+	suite.Equal(ErrNotFound, err)
+
+	j, err := New(&model.Request{}, time.Hour)
+	suite.NoError(err)
+	suite.NoError(suite.store.Put(ctx, j))
+	now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	_, err = suite.store.Get(ctx, j.ID)
+	suite.Equal(ErrNotFound, err, "Expected expired jobs to be missing")
+}
+
+// TestMemoryStoreSuite function
+func TestMemoryStoreSuite(t *testing.T) {
+	suite.Run(t, &StoreSuite{newStore: func() Store { return NewMemory() }})
+}
+
+// TestDynamoStoreSuite function runs against gdpstest.DynamoServer
+func TestDynamoStoreSuite(t *testing.T) {
+	server := gdpstest.NewDynamoServer()
+	defer server.Close()
+
+	// The stand-in doesn't check signatures, but the client needs credentials to sign with
+	os.Setenv("AWS_ACCESS_KEY_ID", "test")
+	os.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+	defer os.Unsetenv("AWS_ACCESS_KEY_ID")
+	defer os.Unsetenv("AWS_SECRET_ACCESS_KEY")
+
+	cfg := &config.Config{}
+	cfg.AWSRegion = "ca-central-1"
+	cfg.DynamoEndpoint = server.URL
+	cfg.JobTable = jobTable
+
+	suite.Run(t, &StoreSuite{newStore: func() Store {
+		server.CreateTable(jobTable, dynamoKey)
+		store, err := NewDynamo(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return store
+	}})
+}
+
+// TestNewStore function
+func TestNewStore(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.JobStore = StoreMemory
+	if store, err := NewStore(cfg); err != nil || store == nil {
+		t.Errorf("Expected a memory store, got %v", err)
+	}
+	cfg.JobStore = StoreDynamo
+	if _, err := NewStore(cfg); err == nil {
+		t.Error("Expected an error without a JobTable")
+	}
+	cfg.JobStore = "redis"
+	if _, err := NewStore(cfg); err == nil {
+		t.Error("Expected an error for an unknown store")
+	}
+}
@@ -0,0 +1,267 @@
+package jsonreport
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"time"
+
+	"github.com/pulpfree/gdps-fs-dwnld/model"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// SchemaVersion of the Document, see schema.json. Bump the major version for
+// changes that break existing consumers.
+const SchemaVersion = "1.0"
+
+// ContentType of the generated document
+const ContentType = "application/json"
+
+// Date formats
+const (
+	timeShortForm = "20060102"
+	timeMonthForm = "200601"
+	dateFrmt      = "2006-01-02"
+	monthFrmt     = "2006-01"
+)
+
+// JSON struct builds a Document from the sections written
+type JSON struct {
+	doc      Document
+	stations map[string]*Station
+}
+
+// Document struct is the normalized report, described by schema.json
+type Document struct {
+	SchemaVersion string       `json:"schemaVersion"`
+	Period        Period       `json:"period"`
+	Stations      []*Station   `json:"stations"`
+	WeeklySales   *WeeklySales `json:"weeklySales,omitempty"`
+}
+
+// Period struct holds the first and last day the report covers
+type Period struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// Station struct holds a station's own sections, each is omitted unless requested
+type Station struct {
+	ID         string      `json:"id"`
+	Name       string      `json:"name"`
+	FuelTypes  []string    `json:"fuelTypes"`
+	Sales      *Sales      `json:"sales,omitempty"`
+	Deliveries *Deliveries `json:"deliveries,omitempty"`
+	OverShort  *OverShort  `json:"overShort,omitempty"`
+	Annual     *Annual     `json:"annualOverShort,omitempty"`
+}
+
+// Sales struct holds the daily litres sold by fuel type
+type Sales struct {
+	Days   []Day              `json:"days"`
+	Totals map[string]float64 `json:"totals"`
+	Total  float64            `json:"total"`
+}
+
+// Deliveries struct holds the daily litres delivered by fuel type
+type Deliveries struct {
+	Days   []Day              `json:"days"`
+	Totals map[string]float64 `json:"totals"`
+}
+
+// Day struct holds a value for each fuel type
+type Day struct {
+	Date   string             `json:"date"`
+	Values map[string]float64 `json:"values"`
+}
+
+// OverShort struct holds the daily tank dips and over-short by fuel type
+type OverShort struct {
+	Days   []OverShortDay     `json:"days"`
+	Totals map[string]float64 `json:"totals"`
+}
+
+// OverShortDay struct
+type OverShortDay struct {
+	Date   string                    `json:"date"`
+	Values map[string]OverShortValue `json:"values"`
+}
+
+// OverShortValue struct
+type OverShortValue struct {
+	TankLitres float64 `json:"tankLitres"`
+	OverShort  float64 `json:"overShort"`
+}
+
+// Annual struct holds the monthly over-short by fuel type
+type Annual struct {
+	Months []Month            `json:"months"`
+	Totals map[string]float64 `json:"totals"`
+}
+
+// Month struct
+type Month struct {
+	Month  string             `json:"month"`
+	Values map[string]float64 `json:"values"`
+}
+
+// WeeklySales struct holds every station's weekly sales, the fuel types are
+// those of the requested by-station sections
+type WeeklySales struct {
+	FuelTypes []string             `json:"fuelTypes"`
+	Weeks     []Week               `json:"weeks"`
+	Stations  []StationWeeklySales `json:"stations"`
+}
+
+// Week struct
+type Week struct {
+	YearWeek  string `json:"yearWeek"`
+	StartDate string `json:"startDate"`
+	EndDate   string `json:"endDate"`
+}
+
+// StationWeeklySales struct
+type StationWeeklySales struct {
+	ID     string             `json:"id"`
+	Name   string             `json:"name"`
+	Weeks  []StationWeek      `json:"weeks"`
+	Totals map[string]float64 `json:"totals"`
+}
+
+// StationWeek struct holds a station's sales for a week and, with no-lead
+// sales, the no-lead price
+type StationWeek struct {
+	YearWeek string             `json:"yearWeek"`
+	Sales    map[string]float64 `json:"sales"`
+	Price    *float64           `json:"price,omitempty"`
+}
+
+// NewFile function
+func NewFile() (j *JSON, err error) {
+	j = &JSON{
+		doc: Document{
+			SchemaVersion: SchemaVersion,
+			Stations:      []*Station{},
+		},
+		stations: make(map[string]*Station),
+	}
+	return j, err
+}
+
+// WriteSection method adds the section called name from sr to the document.
+// The title is not used, stations are identified by their ID.
+func (j *JSON) WriteSection(name, title string, sr *model.StationReport) (err error) {
+
+	if _, err = model.SectionFor(name, sr); err != nil {
+		return err
+	}
+	write, ok := writers[name]
+	if !ok {
+		return fmt.Errorf("No json layout for report section %s", name)
+	}
+	if err = write(j, sr); err != nil {
+		return fmt.Errorf("%s for report section %s", err, name)
+	}
+	return err
+}
+
+// OutputFile method
+func (j *JSON) OutputFile() (buf bytes.Buffer, err error) {
+	enc := json.NewEncoder(&buf)
+	enc.SetIndent("", "  ")
+	if err = enc.Encode(j.doc); err != nil {
+		log.Errorf("json err: %s", err)
+	}
+	return buf, err
+}
+
+// OutputToDisk method
+func (j *JSON) OutputToDisk(path string) (fp string, err error) {
+	buf, err := j.OutputFile()
+	if err != nil {
+		return "", err
+	}
+	err = ioutil.WriteFile(path, buf.Bytes(), 0644)
+	return path, err
+}
+
+// ======================== Helper Methods ================================= //
+
+// station method returns the document entry for the station, adding it
+// and its fuel types as needed
+func (j *JSON) station(id, name string, fuelTypes []string) *Station {
+
+	st, ok := j.stations[id]
+	if !ok {
+		st = &Station{ID: id, Name: name, FuelTypes: []string{}}
+		j.stations[id] = st
+		j.doc.Stations = append(j.doc.Stations, st)
+	}
+	st.FuelTypes = unionFuelTypes(st.FuelTypes, fuelTypes)
+	return st
+}
+
+// setPeriod method sets the document period from the first section written.
+// Reports for a single month have no end date.
+func (j *JSON) setPeriod(start, end time.Time) {
+
+	if j.doc.Period.Start != "" {
+		return
+	}
+	if end.IsZero() {
+		start = time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, start.Location())
+		end = start.AddDate(0, 1, -1)
+	}
+	j.doc.Period = Period{Start: start.Format(dateFrmt), End: end.Format(dateFrmt)}
+}
+
+// unionFuelTypes function returns the fuel types in either list, in model.FuelTypes order
+func unionFuelTypes(a, b []string) []string {
+	all := append(append([]string(nil), a...), b...)
+	res := model.SortFuelTypes(uniqueStrings(all))
+	if res == nil {
+		return []string{}
+	}
+	return res
+}
+
+func uniqueStrings(s []string) (res []string) {
+	seen := make(map[string]bool, len(s))
+	for _, v := range s {
+		if !seen[v] {
+			seen[v] = true
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// values function copies m, so that a missing map is written as an empty object
+func values(m map[string]float64) map[string]float64 {
+	res := make(map[string]float64, len(m))
+	for k, v := range m {
+		res[k] = v
+	}
+	return res
+}
+
+// formatDate function formats the YYYYMMDD dates used by the API
+func formatDate(date int64) string {
+	t, err := time.Parse(timeShortForm, strconv.FormatInt(date, 10))
+	if err != nil {
+		return strconv.FormatInt(date, 10)
+	}
+	return t.Format(dateFrmt)
+}
+
+// formatMonth function formats the YYYYMM months used by the API
+func formatMonth(month string) string {
+	t, err := time.Parse(timeMonthForm, month)
+	if err != nil {
+		return month
+	}
+	return t.Format(monthFrmt)
+}
@@ -0,0 +1,114 @@
+package jsonreport
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pulpfree/gdps-fs-dwnld/fixture"
+	"github.com/pulpfree/gdps-fs-dwnld/model"
+	"github.com/stretchr/testify/suite"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+const fixtureDir = "../fixture/testdata"
+
+// UnitSuite struct
+type UnitSuite struct {
+	suite.Suite
+	file         *JSON
+	sr           *model.StationReport
+	schema       map[string]interface{}
+	schemaLoader gojsonschema.JSONLoader
+}
+
+// SetupTest method
+func (suite *UnitSuite) SetupTest() {
+	var err error
+	suite.file, err = NewFile()
+	suite.NoError(err)
+
+	suite.sr, err = fixture.StationReport(fixtureDir, &model.Request{Date: time.Date(2018, time.August, 1, 0, 0, 0, 0, time.UTC)})
+	suite.NoError(err)
+
+	schema, err := ioutil.ReadFile("schema.json")
+	suite.NoError(err)
+	suite.NoError(json.Unmarshal(schema, &suite.schema))
+	suite.schemaLoader = gojsonschema.NewBytesLoader(schema)
+}
+
+// TestOutputFile method
+func (suite *UnitSuite) TestOutputFile() {
+	for _, name := range model.Sections {
+		suite.NoError(suite.file.WriteSection(name, "", suite.sr), name)
+	}
+
+	doc := suite.output()
+	suite.Equal(SchemaVersion, doc["schemaVersion"])
+	suite.Equal(map[string]interface{}{"start": "2018-08-01", "end": "2018-08-31"}, doc["period"])
+	suite.NoError(suite.validate(doc))
+	doc["extra"] = true
+	suite.Error(suite.validate(doc), "Expected the schema to reject unknown properties")
+
+	d := suite.file.doc
+	suite.Len(d.Stations, 1)
+	st := d.Stations[0]
+	suite.Equal("Test Station", st.Name)
+	suite.Equal([]string{"NL", "SNL", "DSL", "CDSL"}, st.FuelTypes)
+	suite.Len(st.Sales.Days, len(suite.sr.FuelSales.Report.StationSales))
+	suite.Equal("2018-08-01", st.Sales.Days[0].Date)
+	suite.Equal("2018-01", st.Annual.Months[0].Month)
+
+	ws := d.WeeklySales
+	suite.Equal([]string{"NL", "DSL"}, ws.FuelTypes)
+	suite.Len(ws.Weeks, len(suite.sr.FuelSalesList.Report.PeriodHeader))
+	suite.NotNil(ws.Stations[0].Weeks[0].Price)
+}
+
+// TestSections method
+func (suite *UnitSuite) TestSections() {
+	suite.NoError(suite.file.WriteSection(model.SectionFuelSalesDSL, "", suite.sr))
+	suite.NoError(suite.file.WriteSection(model.SectionOverShortMonth, "", suite.sr))
+
+	doc := suite.output()
+	suite.NoError(suite.validate(doc))
+
+	st := suite.file.doc.Stations[0]
+	suite.Nil(st.Sales)
+	suite.NotNil(st.OverShort)
+	suite.Equal([]string{"DSL"}, suite.file.doc.WeeklySales.FuelTypes)
+	suite.Nil(suite.file.doc.WeeklySales.Stations[0].Weeks[0].Price)
+}
+
+// TestSchemaVersion method
+func (suite *UnitSuite) TestSchemaVersion() {
+	desc := suite.schema["description"].(string)
+	suite.True(strings.HasSuffix(desc, "version "+SchemaVersion), "Update schema.json with SchemaVersion")
+}
+
+func (suite *UnitSuite) output() (doc map[string]interface{}) {
+	buf, err := suite.file.OutputFile()
+	suite.NoError(err)
+	suite.NoError(json.Unmarshal(buf.Bytes(), &doc))
+	return doc
+}
+
+// validate method checks doc against schema.json
+func (suite *UnitSuite) validate(doc interface{}) error {
+	res, err := gojsonschema.Validate(suite.schemaLoader, gojsonschema.NewGoLoader(doc))
+	if err != nil {
+		return err
+	}
+	if !res.Valid() {
+		return fmt.Errorf("%v", res.Errors())
+	}
+	return nil
+}
+
+// TestUnitSuite function
+func TestUnitSuite(t *testing.T) {
+	suite.Run(t, new(UnitSuite))
+}
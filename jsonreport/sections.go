@@ -0,0 +1,151 @@
+package jsonreport
+
+import (
+	"sort"
+
+	"github.com/pulpfree/gdps-fs-dwnld/model"
+)
+
+// writeFunc adds a section to the document, sr holds the section's data
+type writeFunc func(j *JSON, sr *model.StationReport) error
+
+// writers maps each section registered with model.RegisterSection to the
+// function adding it to the document
+var writers = map[string]writeFunc{
+	model.SectionFuelSales:       fuelSales,
+	model.SectionFuelSalesNL:     func(j *JSON, sr *model.StationReport) error { return weeklySales(j, sr, "NL") },
+	model.SectionFuelSalesDSL:    func(j *JSON, sr *model.StationReport) error { return weeklySales(j, sr, "DSL") },
+	model.SectionFuelDelivery:    fuelDelivery,
+	model.SectionOverShortMonth:  overShortMonth,
+	model.SectionOverShortAnnual: overShortAnnual,
+}
+
+// fuelSales function
+func fuelSales(j *JSON, sr *model.StationReport) error {
+
+	fs := sr.FuelSales
+	j.setPeriod(fs.Date, fs.EndDate)
+	st := j.station(fs.Station.ID, fs.Station.Name, fs.Report.FuelTypes)
+
+	st.Sales = &Sales{
+		Days:   make([]Day, 0, len(fs.Report.StationSales)),
+		Totals: values(fs.Report.SalesSummary),
+		Total:  fs.Report.SalesTotal,
+	}
+	for _, r := range fs.Report.StationSales {
+		st.Sales.Days = append(st.Sales.Days, Day{Date: formatDate(r.Date), Values: values(r.Sales)})
+	}
+	return nil
+}
+
+// weeklySales function adds the fuelType sales of each station to the
+// weekly sales, with the price for no-lead
+func weeklySales(j *JSON, sr *model.StationReport, fuelType string) error {
+
+	fsl := sr.FuelSalesList
+	j.setPeriod(fsl.Date, fsl.EndDate)
+
+	ws := j.doc.WeeklySales
+	if ws == nil {
+		ws = &WeeklySales{
+			FuelTypes: []string{},
+			Weeks:     make([]Week, 0, len(fsl.Report.PeriodHeader)),
+			Stations:  make([]StationWeeklySales, 0, len(fsl.Report.PeriodSales)),
+		}
+		for _, ph := range fsl.Report.PeriodHeader {
+			ws.Weeks = append(ws.Weeks, Week{YearWeek: ph.YearWeek, StartDate: ph.StartDate, EndDate: ph.EndDate})
+		}
+		for _, ps := range fsl.Report.PeriodSales {
+			sws := StationWeeklySales{
+				ID:     ps.StationID,
+				Name:   ps.StationName,
+				Weeks:  make([]StationWeek, 0, len(fsl.Report.PeriodHeader)),
+				Totals: map[string]float64{},
+			}
+			for _, ph := range fsl.Report.PeriodHeader {
+				sws.Weeks = append(sws.Weeks, StationWeek{YearWeek: ph.YearWeek, Sales: map[string]float64{}})
+			}
+			ws.Stations = append(ws.Stations, sws)
+		}
+		j.doc.WeeklySales = ws
+	}
+	ws.FuelTypes = unionFuelTypes(ws.FuelTypes, []string{fuelType})
+
+	for i, ps := range fsl.Report.PeriodSales {
+		sws := &ws.Stations[i]
+		sws.Totals[fuelType] = ps.StationTotal[fuelType]
+		for w, ph := range fsl.Report.PeriodHeader {
+			sws.Weeks[w].Sales[fuelType] = ps.Periods[w].FuelSales[fuelType]
+			if fuelType == "NL" {
+				price := ps.FuelPrices.Prices[ph.YearWeek]
+				sws.Weeks[w].Price = &price
+			}
+		}
+	}
+	return nil
+}
+
+// fuelDelivery function
+func fuelDelivery(j *JSON, sr *model.StationReport) error {
+
+	fd := sr.FuelDelivery
+	j.setPeriod(fd.Date, fd.EndDate)
+	st := j.station(fd.Station.ID, fd.Station.Name, fd.Report.FuelTypes)
+
+	st.Deliveries = &Deliveries{
+		Days:   make([]Day, 0, len(fd.Report.Deliveries)),
+		Totals: values(fd.Report.DeliverySummary),
+	}
+	for _, r := range fd.Report.Deliveries {
+		vals := make(map[string]float64, len(r.Data))
+		for ft, v := range r.Data {
+			vals[ft] = float64(v)
+		}
+		st.Deliveries.Days = append(st.Deliveries.Days, Day{Date: formatDate(r.Date), Values: vals})
+	}
+	return nil
+}
+
+// overShortMonth function
+func overShortMonth(j *JSON, sr *model.StationReport) error {
+
+	os := sr.OverShortMonth
+	j.setPeriod(os.Date, os.EndDate)
+	st := j.station(os.Station.ID, os.Station.Name, os.Report.FuelTypes)
+
+	st.OverShort = &OverShort{
+		Days:   make([]OverShortDay, 0, len(os.Report.OverShort)),
+		Totals: values(os.Report.OverShortSummary),
+	}
+	for _, r := range os.Report.OverShort {
+		vals := make(map[string]OverShortValue, len(r.Data))
+		for ft, v := range r.Data {
+			vals[ft] = OverShortValue{TankLitres: v.TankLitres, OverShort: v.OverShort}
+		}
+		st.OverShort.Days = append(st.OverShort.Days, OverShortDay{Date: formatDate(r.Date), Values: vals})
+	}
+	return nil
+}
+
+// overShortAnnual function
+func overShortAnnual(j *JSON, sr *model.StationReport) error {
+
+	os := sr.OverShortAnnual
+	j.setPeriod(os.Date, os.EndDate)
+	st := j.station(os.Station.ID, os.Station.Name, os.Report.FuelTypes)
+
+	months := make([]string, 0, len(os.Report.Months))
+	for m := range os.Report.Months {
+		months = append(months, m)
+	}
+	sort.Strings(months)
+
+	st.Annual = &Annual{
+		Months: make([]Month, 0, len(months)),
+		Totals: values(os.Report.Summary),
+	}
+	for _, m := range months {
+		st.Annual.Months = append(st.Annual.Months, Month{Month: formatMonth(m), Values: values(os.Report.Months[m])})
+	}
+	return nil
+}
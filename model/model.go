@@ -2,27 +2,162 @@ package model
 
 import "time"
 
+// Title date formats, see PeriodTitle
+const (
+	dateMonthFormat = "January 2006"
+	dateLongFormat  = "January 2, 2006"
+)
+
 // FuelTypes var
 var FuelTypes = [4]string{"NL", "SNL", "DSL", "CDSL"}
 
+// SortFuelTypes function orders fts to match FuelTypes
+func SortFuelTypes(fts []string) (ret []string) {
+	for _, ft := range FuelTypes {
+		for _, k := range fts {
+			if ft == k {
+				ret = append(ret, ft)
+			}
+		}
+	}
+	return ret
+}
+
+// Report output formats
+const (
+	FormatXLSX = "xlsx"
+	FormatCSV  = "csv" // a zip of one csv file per section
+	FormatPDF  = "pdf"
+	FormatJSON = "json" // see jsonreport.Document
+)
+
+// Formats lists every output format, the first is the default
+var Formats = []string{FormatXLSX, FormatCSV, FormatPDF, FormatJSON}
+
+// GroupFormats lists the formats of group reports, only workbooks have the
+// consolidated summary
+var GroupFormats = []string{FormatXLSX}
+
+// IsGroupFormat function reports whether format is one of GroupFormats
+func IsGroupFormat(format string) bool {
+	for _, f := range GroupFormats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// Station struct
+type Station struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
 // RequestInput struct
 type RequestInput struct {
-	Date      string `json:"date"`
-	StationID string `json:"stationID"`
+	Async        bool     `json:"async"`  // create the report in a job, see job.Job
+	Charts       *bool    `json:"charts"` // optional, xlsx charts default to on
+	Date         string   `json:"date"`
+	EndDate      string   `json:"endDate"`  // with startDate, instead of date
+	Force        bool     `json:"force"`    // skip the report cache
+	Format       string   `json:"format"`   // optional, defaults to xlsx
+	Sections     []string `json:"sections"` // optional, defaults to all sections
+	StartDate    string   `json:"startDate"`
+	StationID    string   `json:"stationID"`
+	StationIDs   []string `json:"stationIDs"`   // consolidated report, instead of stationID
+	StationGroup string   `json:"stationGroup"` // named list of stations, see config StationGroups
+	URLExpiry    int      `json:"urlExpiry"`    // minutes, optional
 }
 
 // Request struct
 type Request struct {
-	Date      time.Time
-	StationID string
+	Async        bool
+	Date         time.Time // month to report, or first month of a range
+	EndDate      time.Time // set for date ranges
+	Force        bool
+	Format       string    // see Formats
+	NoCharts     bool      // leave the charts out of xlsx workbooks
+	Sections     []string  // in sheet order, empty for all sections
+	StartDate    time.Time // set for date ranges
+	StationID    string
+	StationIDs   []string // set for consolidated reports
+	StationGroup string
+	URLExpiry    time.Duration
+}
+
+// IsRange method reports whether the request covers a date range rather than
+// the month of Date
+func (r *Request) IsRange() bool {
+	return !r.EndDate.IsZero()
+}
+
+// SectionList method returns the requested sections, or all of them
+func (r *Request) SectionList() []string {
+	if len(r.Sections) == 0 {
+		return Sections
+	}
+	return r.Sections
+}
+
+// StationList method returns the requested station, or the stations of a
+// consolidated report
+func (r *Request) StationList() []string {
+	if r.StationID != "" {
+		return []string{r.StationID}
+	}
+	return r.StationIDs
+}
+
+// HasSection method reports whether the report includes section
+func (r *Request) HasSection(section string) bool {
+	for _, s := range r.SectionList() {
+		if s == section {
+			return true
+		}
+	}
+	return false
+}
+
+// Months method returns the first day of each month the request covers
+func (r *Request) Months() (months []time.Time) {
+	start, end := r.Date, r.Date
+	if r.IsRange() {
+		start, end = r.StartDate, r.EndDate
+	}
+	m := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, start.Location())
+	for !m.After(end) {
+		months = append(months, m)
+		m = m.AddDate(0, 1, 0)
+	}
+	return months
+}
+
+// PeriodTitle function describes the period a report covers, e.g. August 2018
+// or July 15, 2018 - September 30, 2018. Reports for a single month have no end date.
+func PeriodTitle(start, end time.Time) string {
+
+	if end.IsZero() {
+		return start.Format(dateMonthFormat)
+	}
+	monthStart := start.Day() == 1
+	monthEnd := end.AddDate(0, 0, 1).Day() == 1
+	switch {
+	case monthStart && monthEnd && start.Year() == end.Year() && start.Month() == end.Month():
+		return start.Format(dateMonthFormat)
+	case monthStart && monthEnd:
+		return start.Format(dateMonthFormat) + " - " + end.Format(dateMonthFormat)
+	}
+	return start.Format(dateLongFormat) + " - " + end.Format(dateLongFormat)
 }
 
 // ======================== Qraphql Structs ================================ //
 
 // FuelSales struct
 type FuelSales struct {
-	Date   time.Time
-	Report struct {
+	Date    time.Time
+	EndDate time.Time // set when stitched from several months
+	Report  struct {
 		StationSales []struct {
 			Date  int64
 			Sales map[string]float64
@@ -39,8 +174,9 @@ type FuelSales struct {
 
 // FuelDelivery struct
 type FuelDelivery struct {
-	Date   time.Time
-	Report struct {
+	Date    time.Time
+	EndDate time.Time // set when stitched from several months
+	Report  struct {
 		Deliveries []struct {
 			Date int64
 			Data map[string]int32
@@ -56,8 +192,9 @@ type FuelDelivery struct {
 
 // OverShortMonth struct
 type OverShortMonth struct {
-	Date   time.Time
-	Report struct {
+	Date    time.Time
+	EndDate time.Time // set when stitched from several months
+	Report  struct {
 		OverShort []struct {
 			Date int64
 			Data map[string]struct {
@@ -76,8 +213,9 @@ type OverShortMonth struct {
 
 // OverShortAnnual struct
 type OverShortAnnual struct {
-	Date   time.Time
-	Report struct {
+	Date    time.Time
+	EndDate time.Time // set when stitched from several months
+	Report  struct {
 		Months    map[string]map[string]float64
 		Summary   map[string]float64
 		FuelTypes []string
@@ -91,36 +229,23 @@ type OverShortAnnual struct {
 
 // FuelSalesList struct
 type FuelSalesList struct {
-	Date   time.Time
-	Report struct {
-		PeriodHeader []struct {
-			YearWeek  string
-			StartDate string
-			EndDate   string
-			Week      string
-		}
-		PeriodSales []struct {
-			FuelPrices struct {
-				DateStart int64
-				DateEnd   int64
-				Prices    map[string]float64
-				StationID string
-			}
-			Periods []struct {
-				Dates struct {
-					YearWeek  string
-					StartDate int64
-					EndDate   int64
-				}
-				FuelSales map[string]float64
-			}
-			StationID    string
-			StationName  string
-			StationTotal map[string]float64
-		}
+	Date    time.Time
+	EndDate time.Time // set when stitched from several months
+	Report  struct {
+		PeriodHeader []PeriodHeader
+		PeriodSales  []StationPeriodSales
 	} `json:"fuelSaleListReport"`
 }
 
+// StationReport struct holds every report section for a station
+type StationReport struct {
+	FuelSales       *FuelSales
+	FuelSalesList   *FuelSalesList
+	FuelDelivery    *FuelDelivery
+	OverShortMonth  *OverShortMonth
+	OverShortAnnual *OverShortAnnual
+}
+
 // PeriodHeader struct
 type PeriodHeader struct {
 	YearWeek  string
@@ -128,3 +253,27 @@ type PeriodHeader struct {
 	EndDate   string
 	Week      string
 }
+
+// StationPeriodSales struct holds one station's weekly sales in a FuelSalesList
+type StationPeriodSales struct {
+	FuelPrices struct {
+		DateStart int64
+		DateEnd   int64
+		Prices    map[string]float64
+		StationID string
+	}
+	Periods      []PeriodSales
+	StationID    string
+	StationName  string
+	StationTotal map[string]float64
+}
+
+// PeriodSales struct holds the sales for one week
+type PeriodSales struct {
+	Dates struct {
+		YearWeek  string
+		StartDate int64
+		EndDate   int64
+	}
+	FuelSales map[string]float64
+}
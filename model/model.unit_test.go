@@ -0,0 +1,65 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// UnitSuite struct
+type UnitSuite struct {
+	suite.Suite
+}
+
+// TestPeriodTitle method
+func (suite *UnitSuite) TestPeriodTitle() {
+	suite.Equal("August 2018", PeriodTitle(suite.day("2018-08-01"), time.Time{}))
+	suite.Equal("August 2018", PeriodTitle(suite.day("2018-08-01"), suite.day("2018-08-31")))
+	suite.Equal("July 2018 - September 2018", PeriodTitle(suite.day("2018-07-01"), suite.day("2018-09-30")))
+	suite.Equal("July 15, 2018 - September 30, 2018", PeriodTitle(suite.day("2018-07-15"), suite.day("2018-09-30")))
+}
+
+// TestSections method
+func (suite *UnitSuite) TestSections() {
+	full := &StationReport{
+		FuelSales:       &FuelSales{},
+		FuelSalesList:   &FuelSalesList{},
+		FuelDelivery:    &FuelDelivery{},
+		OverShortMonth:  &OverShortMonth{},
+		OverShortAnnual: &OverShortAnnual{},
+	}
+	for _, name := range Sections {
+		s, err := SectionFor(name, full)
+		suite.NoError(err, name)
+		suite.Equal(name, s.Name)
+		suite.NotEmpty(s.Title, name)
+		suite.True(s.Group.Shared != (s.Group.StationTitle != ""), "Expected a station title for per-station sections only: %s", name)
+
+		_, err = SectionFor(name, &StationReport{})
+		suite.Error(err, "Expected missing data error for %s", name)
+	}
+
+	_, err := SectionFor("unknown", full)
+	suite.EqualError(err, "Unknown report section unknown")
+	suite.True(IsGroupFormat(FormatXLSX))
+	suite.False(IsGroupFormat(FormatCSV))
+}
+
+// TestStationList method
+func (suite *UnitSuite) TestStationList() {
+	suite.Equal([]string{"a"}, (&Request{StationID: "a"}).StationList())
+	suite.Equal([]string{"a", "b"}, (&Request{StationIDs: []string{"a", "b"}}).StationList())
+	suite.Empty((&Request{}).StationList())
+}
+
+func (suite *UnitSuite) day(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	suite.NoError(err)
+	return t
+}
+
+// TestUnitSuite function
+func TestUnitSuite(t *testing.T) {
+	suite.Run(t, new(UnitSuite))
+}
@@ -0,0 +1,141 @@
+package model
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Report section names
+const (
+	SectionFuelSales       = "fuelSales"
+	SectionFuelSalesNL     = "fuelSalesNL"
+	SectionFuelSalesDSL    = "fuelSalesDSL"
+	SectionFuelDelivery    = "fuelDelivery"
+	SectionOverShortMonth  = "overShortMonth"
+	SectionOverShortAnnual = "overShortAnnual"
+)
+
+// Sections lists every report section in the default workbook order
+var Sections = []string{
+	SectionFuelSales,
+	SectionFuelSalesNL,
+	SectionFuelSalesDSL,
+	SectionFuelDelivery,
+	SectionOverShortMonth,
+	SectionOverShortAnnual,
+}
+
+// Data a section is rendered from, one per StationReport field
+const (
+	DataFuelSales       = "fuelSales"
+	DataFuelSalesList   = "fuelSalesList"
+	DataFuelDelivery    = "fuelDelivery"
+	DataOverShortMonth  = "overShortMonth"
+	DataOverShortAnnual = "overShortAnnual"
+)
+
+// Section struct describes a report section whatever the output format. Each
+// format renders registered sections by name, e.g. see xlsx.RegisterSection.
+type Section struct {
+	Name  string // request name, see Sections
+	Title string // sheet, file and page title
+	Data  string // StationReport field the section is rendered from
+	Group GroupSection
+}
+
+// GroupSection struct describes how a section is written in group reports
+type GroupSection struct {
+	Shared       bool   // covers every station so is written once
+	StationTitle string // follows the station name of each station's copy
+}
+
+var (
+	sectionsMu sync.RWMutex
+	sections   = make(map[string]Section)
+)
+
+// RegisterSection function adds s to the section registry, replacing any
+// section registered with the same name
+func RegisterSection(s Section) {
+	sectionsMu.Lock()
+	defer sectionsMu.Unlock()
+	sections[s.Name] = s
+}
+
+// LookupSection function returns the registered section called name
+func LookupSection(name string) (s Section, ok bool) {
+	sectionsMu.RLock()
+	defer sectionsMu.RUnlock()
+	s, ok = sections[name]
+	return s, ok
+}
+
+// SectionFor function returns the registered section called name, or an error
+// if there is none or sr is missing the data it is rendered from
+func SectionFor(name string, sr *StationReport) (s Section, err error) {
+
+	s, ok := LookupSection(name)
+	if !ok {
+		return s, fmt.Errorf("Unknown report section %s", name)
+	}
+	if !HasData(sr, s.Data) {
+		return s, fmt.Errorf("Missing %s data for report section %s", s.Data, name)
+	}
+	return s, err
+}
+
+// HasData function reports whether sr holds the data named data
+func HasData(sr *StationReport, data string) bool {
+	switch data {
+	case DataFuelSales:
+		return sr.FuelSales != nil
+	case DataFuelSalesList:
+		return sr.FuelSalesList != nil
+	case DataFuelDelivery:
+		return sr.FuelDelivery != nil
+	case DataOverShortMonth:
+		return sr.OverShortMonth != nil
+	case DataOverShortAnnual:
+		return sr.OverShortAnnual != nil
+	}
+	return false
+}
+
+func init() {
+	RegisterSection(Section{
+		Name:  SectionFuelSales,
+		Title: "Fuel Sales",
+		Data:  DataFuelSales,
+		Group: GroupSection{StationTitle: "Sales"},
+	})
+	RegisterSection(Section{
+		Name:  SectionFuelSalesNL,
+		Title: "No-Lead Fuel Sales by Station",
+		Data:  DataFuelSalesList,
+		Group: GroupSection{Shared: true},
+	})
+	RegisterSection(Section{
+		Name:  SectionFuelSalesDSL,
+		Title: "Diesel Fuel Sales by Station",
+		Data:  DataFuelSalesList,
+		Group: GroupSection{Shared: true},
+	})
+	RegisterSection(Section{
+		Name:  SectionFuelDelivery,
+		Title: "Fuel Delivery",
+		Data:  DataFuelDelivery,
+		Group: GroupSection{StationTitle: "Delivery"},
+	})
+	RegisterSection(Section{
+		Name:  SectionOverShortMonth,
+		Title: "Over-Short Month",
+		Data:  DataOverShortMonth,
+		Group: GroupSection{StationTitle: "OS Month"},
+	})
+	RegisterSection(Section{
+		Name:  SectionOverShortAnnual,
+		Title: "Over-Short Annual",
+		Data:  DataOverShortAnnual,
+		Group: GroupSection{StationTitle: "OS Annual"},
+	})
+}
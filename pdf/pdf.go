@@ -0,0 +1,288 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/pulpfree/gdps-fs-dwnld/model"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// PDF struct
+type PDF struct {
+	doc    *gofpdf.Fpdf
+	tr     func(string) string // converts UTF-8 to the core font encoding
+	output []byte              // set once the document is closed, see OutputFile
+
+	// Printed at the top of each page of the current section
+	station string
+	title   string
+	period  string
+}
+
+// ContentType of the generated document
+const ContentType = "application/pdf"
+
+// Defaults, lengths are in mm
+const (
+	orientation  = "L"
+	paperSize    = "Letter"
+	margin       = 10.0
+	footerHeight = 6.0
+	rowHeight    = 4.8
+	fontFamily   = "Helvetica"
+	fontSize     = 8.5
+)
+
+// cell struct is a table cell, spanning one column unless span is set
+type cell struct {
+	text  string
+	align string // see gofpdf CellFormat, defaults to right aligned
+	span  int
+	neg   bool // printed in red, as negative over-short values are in the workbook
+}
+
+// page struct holds a rendered section
+type page struct {
+	station string // empty for sections covering every station
+	period  string
+	tables  []*table
+}
+
+// table struct
+type table struct {
+	widths []float64
+	header [][]cell // repeated on each page the table runs on to
+	rows   [][]cell
+	footer []cell // printed in bold
+}
+
+// NewFile function
+func NewFile() (p *PDF, err error) {
+
+	p = new(PDF)
+	p.doc = gofpdf.New(orientation, "mm", paperSize, "")
+	p.doc.SetMargins(margin, margin, margin)
+	p.doc.SetAutoPageBreak(true, margin+footerHeight)
+	p.doc.AliasNbPages("")
+	p.doc.SetHeaderFunc(p.pageHeader)
+	p.doc.SetFooterFunc(p.pageFooter)
+	p.tr = p.doc.UnicodeTranslatorFromDescriptor("")
+
+	if err = p.doc.Error(); err != nil {
+		log.Errorf("pdf err %s: ", err)
+	}
+	return p, err
+}
+
+// WriteSection method renders the section called name from sr starting on a
+// new page. The section is listed in the document outline as bookmark or,
+// if that's empty, the section title.
+func (p *PDF) WriteSection(name, bookmark string, sr *model.StationReport) (err error) {
+
+	s, err := model.SectionFor(name, sr)
+	if err != nil {
+		return err
+	}
+	layout, ok := layouts[name]
+	if !ok {
+		return fmt.Errorf("No pdf layout for report section %s", name)
+	}
+	if bookmark == "" {
+		bookmark = s.Title
+	}
+
+	pg, err := layout(sr)
+	if err != nil {
+		return fmt.Errorf("%s for report section %s", err, name)
+	}
+	p.station, p.title, p.period = pg.station, s.Title, pg.period
+
+	p.doc.AddPage()
+	p.doc.Bookmark(p.tr(bookmark), 0, -1)
+	for _, t := range pg.tables {
+		p.writeTable(t)
+	}
+
+	return p.doc.Error()
+}
+
+// OutputFile method
+func (p *PDF) OutputFile() (buf bytes.Buffer, err error) {
+
+	// Output closes the document so may only be called once
+	if p.output == nil {
+		if err = p.doc.Output(&buf); err != nil {
+			log.Errorf("pdf err: %s", err)
+			return buf, err
+		}
+		p.output = buf.Bytes()
+		return buf, err
+	}
+	_, err = buf.Write(p.output)
+	return buf, err
+}
+
+// OutputToDisk method
+func (p *PDF) OutputToDisk(path string) (fp string, err error) {
+	buf, err := p.OutputFile()
+	if err != nil {
+		return "", err
+	}
+	err = ioutil.WriteFile(path, buf.Bytes(), 0644)
+	return path, err
+}
+
+// ======================== Helper Methods ================================= //
+
+// pageHeader method prints the station, section and period across the top of the page
+func (p *PDF) pageHeader() {
+
+	d := p.doc
+	left, _, right, _ := d.GetMargins()
+	pageW, _ := d.GetPageSize()
+
+	heading := p.title
+	if p.station != "" {
+		heading = p.station + " - " + p.title
+	}
+
+	d.SetTextColor(0, 0, 0)
+	d.SetFont(fontFamily, "B", 12)
+	d.CellFormat(0, 7, p.tr(heading), "", 0, "L", false, 0, "")
+	d.SetFont(fontFamily, "", 10)
+	d.CellFormat(0, 7, p.tr(p.period), "", 1, "R", false, 0, "")
+
+	d.SetDrawColor(0, 0, 0)
+	d.Line(left, d.GetY()+1, pageW-right, d.GetY()+1)
+	d.Ln(4)
+}
+
+// pageFooter method prints the page number
+func (p *PDF) pageFooter() {
+	d := p.doc
+	d.SetY(-(margin + footerHeight/2))
+	d.SetTextColor(100, 100, 100)
+	d.SetFont(fontFamily, "", 8)
+	d.CellFormat(0, 4, fmt.Sprintf("Page %d of {nb}", d.PageNo()), "", 0, "C", false, 0, "")
+}
+
+// writeTable method prints t, starting a new page and repeating the header
+// whenever the next row doesn't fit
+func (p *PDF) writeTable(t *table) {
+
+	_, pageH := p.doc.GetPageSize()
+	_, breakMargin := p.doc.GetAutoPageBreak()
+	fits := func(rows int) bool {
+		return p.doc.GetY()+float64(rows)*rowHeight <= pageH-breakMargin
+	}
+
+	// Keep the header with at least the first row
+	if !fits(len(t.header) + 1) {
+		p.doc.AddPage()
+	}
+	p.writeHeader(t)
+	for _, row := range t.rows {
+		if !fits(1) {
+			p.doc.AddPage()
+			p.writeHeader(t)
+		}
+		p.writeRow(t.widths, row, false)
+	}
+	if t.footer != nil {
+		if !fits(1) {
+			p.doc.AddPage()
+			p.writeHeader(t)
+		}
+		p.writeRow(t.widths, t.footer, true)
+	}
+	p.doc.Ln(rowHeight)
+}
+
+// writeHeader method
+func (p *PDF) writeHeader(t *table) {
+	p.doc.SetFillColor(230, 230, 230)
+	for _, row := range t.header {
+		p.writeRow(t.widths, row, true)
+	}
+}
+
+// writeRow method prints a row of cells, each as wide as the columns it spans
+func (p *PDF) writeRow(widths []float64, row []cell, bold bool) {
+
+	d := p.doc
+	style := ""
+	if bold {
+		style = "B"
+	}
+	d.SetFont(fontFamily, style, fontSize)
+	d.SetDrawColor(180, 180, 180)
+	d.SetLineWidth(0.1)
+
+	col := 0
+	for _, c := range row {
+		span := c.span
+		if span < 1 {
+			span = 1
+		}
+		var w float64
+		for i := col; i < col+span && i < len(widths); i++ {
+			w += widths[i]
+		}
+		col += span
+
+		align := c.align
+		if align == "" {
+			align = "R"
+		}
+		if c.neg {
+			d.SetTextColor(255, 0, 0)
+		} else {
+			d.SetTextColor(0, 0, 0)
+		}
+		d.CellFormat(w, rowHeight, p.tr(c.text), "1", 0, align, bold, 0, "")
+	}
+	d.Ln(-1)
+}
+
+// number function formats num with thousands separators
+func number(num float64, decimals int) cell {
+
+	s := strconv.FormatFloat(math.Abs(num), 'f', decimals, 64)
+	intPart, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, frac = s[:i], s[i:]
+	}
+
+	var b strings.Builder
+	for i, r := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(r)
+	}
+	s = b.String() + frac
+
+	if num < 0 && strings.Trim(s, "0.,") != "" {
+		s = "-" + s
+	}
+	return cell{text: s}
+}
+
+// overShort function formats an over-short value, printed red when negative
+func overShort(num float64) cell {
+	c := number(num, 2)
+	c.neg = strings.HasPrefix(c.text, "-")
+	return c
+}
+
+// text function returns a left aligned cell
+func text(s string) cell {
+	return cell{text: s, align: "L"}
+}
@@ -0,0 +1,95 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pulpfree/gdps-fs-dwnld/fixture"
+	"github.com/pulpfree/gdps-fs-dwnld/model"
+	"github.com/stretchr/testify/suite"
+)
+
+const fixtureDir = "../fixture/testdata"
+
+// UnitSuite struct
+type UnitSuite struct {
+	suite.Suite
+	file *PDF
+	sr   *model.StationReport
+}
+
+// SetupTest method
+func (suite *UnitSuite) SetupTest() {
+	var err error
+	suite.file, err = NewFile()
+	suite.NoError(err)
+
+	suite.sr, err = fixture.StationReport(fixtureDir, &model.Request{Date: time.Date(2018, time.August, 1, 0, 0, 0, 0, time.UTC)})
+	suite.NoError(err)
+}
+
+// TestOutputFile method
+func (suite *UnitSuite) TestOutputFile() {
+	for _, name := range model.Sections {
+		suite.NoError(suite.file.WriteSection(name, "", suite.sr), name)
+	}
+	suite.Equal(len(model.Sections), suite.file.doc.PageCount())
+
+	buf, err := suite.file.OutputFile()
+	suite.NoError(err)
+	suite.True(bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")))
+
+	// The document is closed by the first call
+	again, err := suite.file.OutputFile()
+	suite.NoError(err)
+	suite.Equal(buf.Bytes(), again.Bytes())
+}
+
+// TestLongSection method
+func (suite *UnitSuite) TestLongSection() {
+	fs := *suite.sr.FuelSales
+	fs.Report.StationSales = nil
+	for i := 0; i < 3; i++ {
+		fs.Report.StationSales = append(fs.Report.StationSales, suite.sr.FuelSales.Report.StationSales...)
+	}
+	suite.NoError(suite.file.WriteSection(model.SectionFuelSales, "", &model.StationReport{FuelSales: &fs}))
+	suite.True(suite.file.doc.PageCount() > 1, "Expected rows to continue on further pages")
+}
+
+// TestWideStationList method
+func (suite *UnitSuite) TestWideStationList() {
+	fsl := &model.FuelSalesList{Date: time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)}
+	sales := model.StationPeriodSales{
+		StationName:  "Wide Station",
+		StationTotal: map[string]float64{"NL": 200, "DSL": 100},
+	}
+	for i := 0; i < 12; i++ {
+		fsl.Report.PeriodHeader = append(fsl.Report.PeriodHeader, model.PeriodHeader{YearWeek: fmt.Sprintf("2018%02d", i+1)})
+		sales.Periods = append(sales.Periods, model.PeriodSales{FuelSales: map[string]float64{"NL": 10, "DSL": 5}})
+	}
+	fsl.Report.PeriodSales = []model.StationPeriodSales{sales}
+
+	pg, err := fuelSalesListNL(&model.StationReport{FuelSalesList: fsl})
+	suite.NoError(err)
+	suite.Len(pg.tables, 3)
+	last := pg.tables[2]
+	suite.Len(last.widths, 1+2*2+1)
+	suite.Equal("120", last.rows[0][len(last.rows[0])-1].text)
+}
+
+// TestNumber method
+func (suite *UnitSuite) TestNumber() {
+	suite.Equal("1,234,568", number(1234567.8, 0).text)
+	suite.Equal("-1,234.50", number(-1234.5, 2).text)
+	suite.Equal("0", number(-0.2, 0).text)
+	suite.True(overShort(-31.35).neg)
+	suite.False(overShort(-0.001).neg)
+	suite.False(number(-5, 0).neg, "Only over-short values are printed red")
+}
+
+// TestUnitSuite function
+func TestUnitSuite(t *testing.T) {
+	suite.Run(t, new(UnitSuite))
+}
@@ -0,0 +1,339 @@
+package pdf
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pulpfree/gdps-fs-dwnld/model"
+)
+
+// Column widths in mm, the page is 259.4mm wide between the margins
+const (
+	dateColWidth  = 30.0
+	numColWidth   = 30.0
+	nameColWidth  = 50.0
+	weekColWidth  = 18.0
+	priceColWidth = 14.0
+	totalColWidth = 24.0
+
+	// weeks of the station list that fit across the page, after the
+	// station name and total, at weekColWidth+priceColWidth each
+	weeksPerTable = 5
+)
+
+// Date formats
+const (
+	timeShortForm  = "20060102"
+	timeMonthForm  = "200601"
+	weekDateForm   = "2006-01-02"
+	dateDayFormat  = "Jan _2"
+	dateMonthFrmt  = "January"
+	dateMonthsFrmt = "January 2006"
+)
+
+// layoutFunc lays out a section on a page, sr holds the section's data
+type layoutFunc func(sr *model.StationReport) (*page, error)
+
+// layouts maps each section registered with model.RegisterSection to its layout
+var layouts = map[string]layoutFunc{
+	model.SectionFuelSales:       fuelSales,
+	model.SectionFuelSalesNL:     fuelSalesListNL,
+	model.SectionFuelSalesDSL:    fuelSalesListDSL,
+	model.SectionFuelDelivery:    fuelDelivery,
+	model.SectionOverShortMonth:  overShortMonth,
+	model.SectionOverShortAnnual: overShortAnnual,
+}
+
+// fuelSales function lays out the daily sales table with the month's total sales below
+func fuelSales(sr *model.StationReport) (*page, error) {
+
+	fs := sr.FuelSales
+	fuelTypes := fs.Report.FuelTypes
+
+	t := fuelTypeTable("Date", fuelTypes)
+	for _, r := range fs.Report.StationSales {
+		row := []cell{text(formatDay(r.Date))}
+		for _, ft := range fuelTypes {
+			row = append(row, number(r.Sales[ft], 0))
+		}
+		t.rows = append(t.rows, row)
+	}
+	t.footer = []cell{text("Total")}
+	for _, ft := range fuelTypes {
+		t.footer = append(t.footer, number(fs.Report.SalesSummary[ft], 0))
+	}
+
+	total := &table{
+		widths: []float64{dateColWidth, numColWidth},
+		footer: []cell{text("Total Sales"), number(fs.Report.SalesTotal, 0)},
+	}
+
+	return &page{
+		station: fs.Station.Name,
+		period:  model.PeriodTitle(fs.Date, fs.EndDate),
+		tables:  []*table{t, total},
+	}, nil
+}
+
+// fuelSalesListNL function lists each station's weekly no-lead sales and
+// price. Weeks that don't fit across the page continue in a further table.
+func fuelSalesListNL(sr *model.StationReport) (*page, error) {
+
+	fsl := sr.FuelSalesList
+
+	pg := &page{period: model.PeriodTitle(fsl.Date, fsl.EndDate)}
+	weeks := fsl.Report.PeriodHeader
+	for start := 0; start < len(weeks); start += weeksPerTable {
+		end := start + weeksPerTable
+		if end > len(weeks) {
+			end = len(weeks)
+		}
+		last := end == len(weeks)
+
+		t := &table{widths: []float64{nameColWidth}}
+		top := []cell{{}}
+		sub := []cell{text("Station")}
+		for _, ph := range weeks[start:end] {
+			t.widths = append(t.widths, weekColWidth, priceColWidth)
+			top = append(top, cell{text: formatWeek(ph), align: "C", span: 2})
+			sub = append(sub, cell{text: "Sales", align: "C"}, cell{text: "Price", align: "C"})
+		}
+		if last {
+			t.widths = append(t.widths, totalColWidth)
+			top = append(top, cell{})
+			sub = append(sub, cell{text: "Total", align: "C"})
+		}
+		t.header = [][]cell{top, sub}
+
+		weekTotals := make([]float64, end-start)
+		var total float64
+		for _, sales := range fsl.Report.PeriodSales {
+			row := []cell{text(sales.StationName)}
+			var stationTotal float64
+			for i, ph := range weeks {
+				val := sales.Periods[i].FuelSales["NL"]
+				stationTotal += val
+				if i < start || i >= end {
+					continue
+				}
+				weekTotals[i-start] += val
+				row = append(row, number(val, 0), number(sales.FuelPrices.Prices[ph.YearWeek], 2))
+			}
+			if last {
+				row = append(row, number(stationTotal, 0))
+				total += stationTotal
+			}
+			t.rows = append(t.rows, row)
+		}
+
+		t.footer = []cell{text("Total")}
+		for _, val := range weekTotals {
+			t.footer = append(t.footer, number(val, 0), cell{})
+		}
+		if last {
+			t.footer = append(t.footer, number(total, 0))
+		}
+		pg.tables = append(pg.tables, t)
+	}
+
+	return pg, nil
+}
+
+// fuelSalesListDSL function lays out each station's weekly diesel sales, as
+// fuelSalesListNL without the prices. Stations that sold no diesel are skipped.
+func fuelSalesListDSL(sr *model.StationReport) (*page, error) {
+
+	fsl := sr.FuelSalesList
+
+	pg := &page{period: model.PeriodTitle(fsl.Date, fsl.EndDate)}
+	weeks := fsl.Report.PeriodHeader
+	for start := 0; start < len(weeks); start += weeksPerTable {
+		end := start + weeksPerTable
+		if end > len(weeks) {
+			end = len(weeks)
+		}
+		last := end == len(weeks)
+
+		t := &table{widths: []float64{nameColWidth}}
+		head := []cell{text("Station")}
+		for _, ph := range weeks[start:end] {
+			t.widths = append(t.widths, weekColWidth+priceColWidth)
+			head = append(head, cell{text: formatWeek(ph), align: "C"})
+		}
+		if last {
+			t.widths = append(t.widths, totalColWidth)
+			head = append(head, cell{text: "Total", align: "C"})
+		}
+		t.header = [][]cell{head}
+
+		weekTotals := make([]float64, end-start)
+		var total float64
+		for _, sales := range fsl.Report.PeriodSales {
+			if sales.StationTotal["DSL"] <= 0 {
+				continue
+			}
+			row := []cell{text(sales.StationName)}
+			var stationTotal float64
+			for i := range weeks {
+				val := sales.Periods[i].FuelSales["DSL"]
+				stationTotal += val
+				if i < start || i >= end {
+					continue
+				}
+				weekTotals[i-start] += val
+				row = append(row, number(val, 0))
+			}
+			if last {
+				row = append(row, number(stationTotal, 0))
+				total += stationTotal
+			}
+			t.rows = append(t.rows, row)
+		}
+
+		t.footer = []cell{text("Total")}
+		for _, val := range weekTotals {
+			t.footer = append(t.footer, number(val, 0))
+		}
+		if last {
+			t.footer = append(t.footer, number(total, 0))
+		}
+		pg.tables = append(pg.tables, t)
+	}
+
+	return pg, nil
+}
+
+// fuelDelivery function lays out the daily deliveries, leaving days without a
+// delivery of a fuel type blank
+func fuelDelivery(sr *model.StationReport) (*page, error) {
+
+	fd := sr.FuelDelivery
+	fuelTypes := fd.Report.FuelTypes
+
+	t := fuelTypeTable("Date", fuelTypes)
+	for _, r := range fd.Report.Deliveries {
+		row := []cell{text(formatDay(r.Date))}
+		for _, ft := range fuelTypes {
+			if r.Data[ft] > 0 {
+				row = append(row, number(float64(r.Data[ft]), 0))
+			} else {
+				row = append(row, cell{})
+			}
+		}
+		t.rows = append(t.rows, row)
+	}
+	t.footer = []cell{text("Total")}
+	for _, ft := range fuelTypes {
+		t.footer = append(t.footer, number(fd.Report.DeliverySummary[ft], 0))
+	}
+
+	return &page{
+		station: fd.Station.Name,
+		period:  model.PeriodTitle(fd.Date, fd.EndDate),
+		tables:  []*table{t},
+	}, nil
+}
+
+// overShortMonth function lays out the daily over-short with shortfalls in red
+func overShortMonth(sr *model.StationReport) (*page, error) {
+
+	os := sr.OverShortMonth
+	fuelTypes := os.Report.FuelTypes
+
+	t := fuelTypeTable("Date", fuelTypes)
+	for _, r := range os.Report.OverShort {
+		row := []cell{text(formatDay(r.Date))}
+		for _, ft := range fuelTypes {
+			row = append(row, overShort(r.Data[ft].OverShort))
+		}
+		t.rows = append(t.rows, row)
+	}
+	t.footer = []cell{text("Total")}
+	for _, ft := range fuelTypes {
+		t.footer = append(t.footer, overShort(os.Report.OverShortSummary[ft]))
+	}
+
+	return &page{
+		station: os.Station.Name,
+		period:  model.PeriodTitle(os.Date, os.EndDate),
+		tables:  []*table{t},
+	}, nil
+}
+
+// overShortAnnual function lays out the monthly over-short. Months carry the
+// year when a date range may cross a year end.
+func overShortAnnual(sr *model.StationReport) (*page, error) {
+
+	os := sr.OverShortAnnual
+	fuelTypes := os.Report.FuelTypes
+
+	var months []string
+	for m := range os.Report.Months {
+		months = append(months, m)
+	}
+	sort.Strings(months)
+
+	// Date ranges may start mid year and cross a year end
+	monthFrmt := dateMonthFrmt
+	if !os.EndDate.IsZero() {
+		monthFrmt = dateMonthsFrmt
+	}
+
+	t := fuelTypeTable("Month", fuelTypes)
+	for _, m := range months {
+		label := m
+		if tm, err := time.Parse(timeMonthForm, m); err == nil {
+			label = tm.Format(monthFrmt)
+		}
+		row := []cell{text(label)}
+		for _, ft := range fuelTypes {
+			row = append(row, overShort(os.Report.Months[m][ft]))
+		}
+		t.rows = append(t.rows, row)
+	}
+	t.footer = []cell{text("Total")}
+	for _, ft := range fuelTypes {
+		t.footer = append(t.footer, overShort(os.Report.Summary[ft]))
+	}
+
+	return &page{
+		station: os.Station.Name,
+		period:  model.PeriodTitle(os.Date, os.EndDate),
+		tables:  []*table{t},
+	}, nil
+}
+
+// fuelTypeTable function returns a table with a column for each fuel type
+func fuelTypeTable(label string, fuelTypes []string) *table {
+	t := &table{widths: []float64{dateColWidth}}
+	head := []cell{text(label)}
+	for _, ft := range fuelTypes {
+		t.widths = append(t.widths, numColWidth)
+		head = append(head, cell{text: ft, align: "C"})
+	}
+	t.header = [][]cell{head}
+	return t
+}
+
+// formatDay function formats the YYYYMMDD dates used by the API
+func formatDay(date int64) string {
+	t, err := time.Parse(timeShortForm, strconv.FormatInt(date, 10))
+	if err != nil {
+		return strconv.FormatInt(date, 10)
+	}
+	return t.Format(dateDayFormat)
+}
+
+// formatWeek function describes a week of the station list, e.g. Jul 29 - Aug 4
+func formatWeek(ph model.PeriodHeader) string {
+	start, err := time.Parse(weekDateForm, ph.StartDate)
+	if err != nil {
+		return ph.StartDate + " - " + ph.EndDate
+	}
+	end, err := time.Parse(weekDateForm, ph.EndDate)
+	if err != nil {
+		return ph.StartDate + " - " + ph.EndDate
+	}
+	return start.Format("Jan 2") + " - " + end.Format("Jan 2")
+}
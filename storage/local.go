@@ -0,0 +1,223 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const metaExt = ".meta"
+
+// Local struct stores reports on the local filesystem. Files are served by
+// Handler through links signed with an HMAC of the key and expiry time.
+type Local struct {
+	dir     string
+	baseURL *url.URL
+	secret  []byte
+	now     func() time.Time
+}
+
+// NewLocal function
+func NewLocal(dir, baseURL, secret string) (l *Local, err error) {
+
+	if dir == "" {
+		return nil, errors.New("Missing LocalStorageDir")
+	}
+	if secret == "" {
+		return nil, errors.New("Missing LocalStorageSecret")
+	}
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, errors.New("Invalid LocalStorageURL: " + baseURL)
+	}
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	l = &Local{
+		dir:     dir,
+		baseURL: u,
+		secret:  []byte(secret),
+		now:     time.Now,
+	}
+	return l, err
+}
+
+// Put method
+func (l *Local) Put(ctx context.Context, key string, body io.Reader, opts PutOptions) (err error) {
+
+	fp, err := l.filePath(key)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(fp), 0755); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if _, err = io.Copy(&buf, body); err != nil {
+		return err
+	}
+	if err = ioutil.WriteFile(fp, buf.Bytes(), 0644); err != nil {
+		return err
+	}
+
+	meta, err := json.Marshal(opts)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(fp+metaExt, meta, 0644)
+}
+
+// Get method
+func (l *Local) Get(ctx context.Context, key string) (body []byte, err error) {
+
+	fp, err := l.filePath(key)
+	if err != nil {
+		return nil, err
+	}
+	body, err = ioutil.ReadFile(fp)
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	return body, err
+}
+
+// Exists method
+func (l *Local) Exists(ctx context.Context, key string) (bool, error) {
+
+	fp, err := l.filePath(key)
+	if err != nil {
+		return false, err
+	}
+	_, err = os.Stat(fp)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Delete method
+func (l *Local) Delete(ctx context.Context, key string) (err error) {
+
+	fp, err := l.filePath(key)
+	if err != nil {
+		return err
+	}
+	for _, f := range []string{fp, fp + metaExt} {
+		if err = os.Remove(f); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// SignedURL method
+func (l *Local) SignedURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+
+	if _, err := l.filePath(key); err != nil {
+		return "", err
+	}
+	expires := strconv.FormatInt(l.now().Add(expiry).Unix(), 10)
+
+	u := *l.baseURL
+	u.Path = path.Join(u.Path, key)
+	q := url.Values{}
+	q.Set("expires", expires)
+	q.Set("signature", l.sign(key, expires))
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+// Handler method serves files requested through links created by SignedURL
+func (l *Local) Handler() http.Handler {
+
+	prefix := strings.TrimSuffix(l.baseURL.Path, "/")
+	return http.StripPrefix(prefix, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		key := strings.TrimPrefix(r.URL.Path, "/")
+		expires := r.URL.Query().Get("expires")
+		if !l.verify(key, expires, r.URL.Query().Get("signature")) {
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+
+		fp, err := l.filePath(key)
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		}
+		file, err := os.Open(fp)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		defer file.Close()
+
+		var opts PutOptions
+		if meta, err := ioutil.ReadFile(fp + metaExt); err == nil {
+			json.Unmarshal(meta, &opts)
+		}
+		if opts.ContentType != "" {
+			w.Header().Set("Content-Type", opts.ContentType)
+		}
+		if opts.ContentDisposition != "" {
+			w.Header().Set("Content-Disposition", opts.ContentDisposition)
+		}
+
+		info, err := file.Stat()
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		http.ServeContent(w, r, path.Base(key), info.ModTime(), file)
+	}))
+}
+
+// sign method returns the hex encoded HMAC for key and expires
+func (l *Local) sign(key, expires string) string {
+	mac := hmac.New(sha256.New, l.secret)
+	mac.Write([]byte(key + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// verify method checks the signature and that the link has not expired
+func (l *Local) verify(key, expires, signature string) bool {
+
+	exp, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || l.now().Unix() > exp {
+		return false
+	}
+	expected := l.sign(key, expires)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+// filePath method maps key to a path within dir, rejecting keys that escape it
+func (l *Local) filePath(key string) (string, error) {
+
+	clean := path.Clean("/" + key)
+	if key == "" || clean != "/"+key || strings.HasSuffix(key, metaExt) {
+		return "", errors.New("storage: invalid key: " + key)
+	}
+	return filepath.Join(l.dir, filepath.FromSlash(clean)), nil
+}
@@ -0,0 +1,133 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	fileKey = "Test Station_StationReport_2018-08.xlsx"
+	secret  = "test-secret"
+)
+
+// LocalSuite struct
+type LocalSuite struct {
+	suite.Suite
+	dir    string
+	server *httptest.Server
+	store  *Local
+	ctx    context.Context
+}
+
+// SetupTest method
+func (suite *LocalSuite) SetupTest() {
+	var err error
+	suite.ctx = context.Background()
+	suite.dir, err = ioutil.TempDir("", "storage")
+	suite.NoError(err)
+
+	mux := http.NewServeMux()
+	suite.server = httptest.NewServer(mux)
+	suite.store, err = NewLocal(suite.dir, suite.server.URL+"/files", secret)
+	suite.NoError(err)
+	mux.Handle("/files/", suite.store.Handler())
+
+	err = suite.store.Put(suite.ctx, fileKey, bytes.NewBufferString("report"), PutOptions{
+		ContentType:        "text/plain",
+		ContentDisposition: "attachment",
+	})
+	suite.NoError(err)
+}
+
+// TearDownTest method
+func (suite *LocalSuite) TearDownTest() {
+	suite.server.Close()
+	os.RemoveAll(suite.dir)
+}
+
+// TestGetExistsDelete method
+func (suite *LocalSuite) TestGetExistsDelete() {
+	body, err := suite.store.Get(suite.ctx, fileKey)
+	suite.NoError(err)
+	suite.Equal("report", string(body))
+
+	exists, err := suite.store.Exists(suite.ctx, fileKey)
+	suite.NoError(err)
+	suite.True(exists)
+
+	suite.NoError(suite.store.Delete(suite.ctx, fileKey))
+	exists, err = suite.store.Exists(suite.ctx, fileKey)
+	suite.NoError(err)
+	suite.False(exists)
+
+	_, err = suite.store.Get(suite.ctx, fileKey)
+	suite.Equal(ErrNotFound, err)
+}
+
+// TestSignedURL method
+func (suite *LocalSuite) TestSignedURL() {
+	url, err := suite.store.SignedURL(suite.ctx, fileKey, time.Minute)
+	suite.NoError(err)
+
+	res, err := http.Get(url)
+	suite.NoError(err)
+	defer res.Body.Close()
+	body, _ := ioutil.ReadAll(res.Body)
+
+	suite.Equal(http.StatusOK, res.StatusCode)
+	suite.Equal("report", string(body))
+	suite.Equal("text/plain", res.Header.Get("Content-Type"))
+	suite.Equal("attachment", res.Header.Get("Content-Disposition"))
+}
+
+// TestSignedURLExpired method
+func (suite *LocalSuite) TestSignedURLExpired() {
+	url, err := suite.store.SignedURL(suite.ctx, fileKey, -time.Minute)
+	suite.NoError(err)
+
+	res, err := http.Get(url)
+	suite.NoError(err)
+	res.Body.Close()
+	suite.Equal(http.StatusForbidden, res.StatusCode)
+}
+
+// TestSignedURLTampered method
+func (suite *LocalSuite) TestSignedURLTampered() {
+	url, err := suite.store.SignedURL(suite.ctx, fileKey, time.Minute)
+	suite.NoError(err)
+
+	res, err := http.Get(strings.Replace(url, "2018-08", "2018-09", 1))
+	suite.NoError(err)
+	res.Body.Close()
+	suite.Equal(http.StatusForbidden, res.StatusCode)
+}
+
+// TestAttachmentDisposition method
+func (suite *LocalSuite) TestAttachmentDisposition() {
+	suite.Equal(`attachment; filename="Test Station_2018-08.xlsx"; filename*=UTF-8''Test%20Station_2018-08.xlsx`,
+		AttachmentDisposition("Test Station_2018-08.xlsx"))
+	suite.Equal(`attachment; filename="Sainte-Lucie _le_2018-08.xlsx"; filename*=UTF-8''Sainte-Lucie%20%C3%AEle_2018-08.xlsx`,
+		AttachmentDisposition("Sainte-Lucie île_2018-08.xlsx"))
+}
+
+// TestInvalidKey method
+func (suite *LocalSuite) TestInvalidKey() {
+	for _, key := range []string{"", "../escape.xlsx", "/abs.xlsx", fileKey + metaExt} {
+		err := suite.store.Put(suite.ctx, key, bytes.NewBufferString("x"), PutOptions{})
+		suite.Error(err, key)
+	}
+}
+
+// TestLocalSuite function
+func TestLocalSuite(t *testing.T) {
+	suite.Run(t, new(LocalSuite))
+}
@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/pulpfree/gdps-fs-dwnld/awsservices"
+	"github.com/pulpfree/gdps-fs-dwnld/config"
+)
+
+// S3 struct stores reports in the configured S3 bucket
+type S3 struct {
+	serv *awsservices.S3Service
+}
+
+// NewS3 function
+func NewS3(cfg *config.Config) (s *S3, err error) {
+	serv, err := awsservices.NewS3(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &S3{serv: serv}, err
+}
+
+// Put method
+func (s *S3) Put(ctx context.Context, key string, body io.Reader, opts PutOptions) error {
+	return s.serv.PutFile(ctx, key, body, opts.ContentType, opts.ContentDisposition)
+}
+
+// Get method
+func (s *S3) Get(ctx context.Context, key string) (body []byte, err error) {
+	body, err = s.serv.GetFile(ctx, key)
+	if awsservices.IsNotFound(err) {
+		return nil, ErrNotFound
+	}
+	return body, err
+}
+
+// Exists method
+func (s *S3) Exists(ctx context.Context, key string) (bool, error) {
+	return s.serv.HasFile(ctx, key)
+}
+
+// Delete method
+func (s *S3) Delete(ctx context.Context, key string) error {
+	return s.serv.DeleteFile(ctx, key)
+}
+
+// SignedURL method
+func (s *S3) SignedURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	return s.serv.SignURL(key, expiry)
+}
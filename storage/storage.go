@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/pulpfree/gdps-fs-dwnld/config"
+)
+
+// Backend names used by the StorageBackend config value
+const (
+	BackendS3    = "s3"
+	BackendLocal = "local"
+)
+
+// ErrNotFound is returned by Get when the key does not exist
+var ErrNotFound = errors.New("storage: file not found")
+
+// PutOptions struct
+type PutOptions struct {
+	ContentType        string
+	ContentDisposition string
+}
+
+// Storage interface is implemented by each report storage backend
+type Storage interface {
+	Put(ctx context.Context, key string, body io.Reader, opts PutOptions) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Exists(ctx context.Context, key string) (bool, error)
+	Delete(ctx context.Context, key string) error
+	SignedURL(ctx context.Context, key string, expiry time.Duration) (string, error)
+}
+
+// New function returns the backend selected by cfg.StorageBackend
+func New(cfg *config.Config) (Storage, error) {
+
+	switch cfg.StorageBackend {
+	case "", BackendS3:
+		return NewS3(cfg)
+	case BackendLocal:
+		return NewLocal(cfg.LocalStorageDir, cfg.LocalStorageURL, cfg.LocalStorageSecret)
+	}
+	return nil, fmt.Errorf("Invalid StorageBackend: %s", cfg.StorageBackend)
+}
@@ -0,0 +1,86 @@
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/pulpfree/gdps-fs-dwnld/model"
+)
+
+// MaxBodyBytes is the largest request body accepted
+const MaxBodyBytes = 16 << 10
+
+// bodyField names problems with the request as a whole
+const bodyField = "body"
+
+// Decode function strictly decodes a RequestInput from body. Bodies over
+// MaxBodyBytes, invalid JSON, unknown fields and values of the wrong type
+// are rejected.
+func Decode(body io.Reader) (*model.RequestInput, error) {
+
+	buf, err := ioutil.ReadAll(io.LimitReader(body, MaxBodyBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(buf) > MaxBodyBytes {
+		return nil, Errors{{Field: bodyField, Error: fmt.Sprintf("must not be larger than %d bytes", MaxBodyBytes)}}
+	}
+	if len(bytes.TrimSpace(buf)) == 0 {
+		return nil, Errors{{Field: bodyField, Error: "is required"}}
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(buf))
+	dec.DisallowUnknownFields()
+
+	var r model.RequestInput
+	if err = dec.Decode(&r); err != nil {
+		return nil, Errors{decodeError(err)}
+	}
+	if dec.More() {
+		return nil, Errors{{Field: bodyField, Error: "must hold a single JSON object"}}
+	}
+	return &r, nil
+}
+
+// decodeError function describes a json decoding error
+func decodeError(err error) FieldError {
+
+	var (
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+	)
+	switch {
+	case errors.As(err, &syntaxErr):
+		return FieldError{Field: bodyField, Error: fmt.Sprintf("must be valid JSON, error at offset %d", syntaxErr.Offset)}
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		return FieldError{Field: typeErr.Field, Error: "must be " + jsonType(typeErr.Type.Kind().String())}
+	case errors.As(err, &typeErr):
+		return FieldError{Field: bodyField, Error: "must be a JSON object"}
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return FieldError{Field: bodyField, Error: "must be valid JSON, unexpected end of input"}
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		return FieldError{Field: field, Error: "is not a known field"}
+	}
+	return FieldError{Field: bodyField, Error: err.Error()}
+}
+
+// jsonType function names the JSON type expected for a Go kind
+func jsonType(kind string) string {
+	switch kind {
+	case "string":
+		return "a string"
+	case "bool":
+		return "true or false"
+	case "slice":
+		return "an array"
+	case "int", "int32", "int64":
+		return "a whole number"
+	}
+	return "a " + kind
+}
@@ -0,0 +1,37 @@
+package validate
+
+import (
+	"strings"
+)
+
+// TestDecode method
+func (suite *UnitSuite) TestDecode() {
+	r, err := Decode(strings.NewReader(`{"date":"2018-05-01","stationID":"` + stationID + `","sections":["fuelSales"]}`))
+	suite.NoError(err)
+	suite.Equal(date, r.Date)
+	suite.Equal(stationID, r.StationID)
+	suite.Equal([]string{"fuelSales"}, r.Sections)
+}
+
+// TestDecodeErrors method
+func (suite *UnitSuite) TestDecodeErrors() {
+	bad := map[string]FieldError{
+		``:                                   {Field: "body", Error: "is required"},
+		`{"date":"2018-05-01"`:               {Field: "body", Error: "must be valid JSON, unexpected end of input"},
+		`{"date":2018-05-01}`:                {Field: "body", Error: "must be valid JSON, error at offset 13"},
+		`["2018-05-01"]`:                     {Field: "body", Error: "must be a JSON object"},
+		`{"date":"2018-05-01"} {}`:           {Field: "body", Error: "must hold a single JSON object"},
+		`{"station":"` + stationID + `"}`:    {Field: "station", Error: "is not a known field"},
+		`{"date":20180501}`:                  {Field: "date", Error: "must be a string"},
+		`{"urlExpiry":"60"}`:                 {Field: "urlExpiry", Error: "must be a whole number"},
+		`{"stationIDs":"` + stationID + `"}`: {Field: "stationIDs", Error: "must be an array"},
+		`{"charts":"no"}`:                    {Field: "charts", Error: "must be true or false"},
+	}
+	for body, fe := range bad {
+		_, err := Decode(strings.NewReader(body))
+		suite.Equal(Errors{fe}, err, body)
+	}
+
+	_, err := Decode(strings.NewReader(`{"date":"` + strings.Repeat(" ", MaxBodyBytes) + `"}`))
+	suite.Equal(Errors{{Field: "body", Error: "must not be larger than 16384 bytes"}}, err)
+}
@@ -0,0 +1,36 @@
+package validate
+
+import (
+	"strings"
+)
+
+// FieldError struct describes a problem with one request field. Field is
+// the JSON name, or body for problems with the request as a whole.
+type FieldError struct {
+	Field string `json:"field"`
+	Error string `json:"error"`
+}
+
+// Errors type holds every problem found with a request
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fe.Field + " " + fe.Error
+	}
+	return "Invalid request. " + strings.Join(msgs, "; ")
+}
+
+// add method appends a problem with field
+func (e *Errors) add(field, msg string) {
+	*e = append(*e, FieldError{Field: field, Error: msg})
+}
+
+// err method returns e as an error, or nil when there are no problems
+func (e Errors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
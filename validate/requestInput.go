@@ -2,6 +2,8 @@ package validate
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/pulpfree/gdps-fs-dwnld/model"
@@ -13,40 +15,233 @@ const (
 	timeRecordForm = "2006-01-02"
 )
 
+// MaxRangeMonths is the most calendar months a date range may touch
+const MaxRangeMonths = 24
+
+// Date errors, worded to follow the field name
+var (
+	ErrDateFormat = errors.New("must be YYYY-MM-DD")
+	ErrDateFuture = errors.New("must not be in the future")
+)
+
 // Date function
 func Date(dateInput string) (time.Time, error) {
 
 	date, err := time.Parse(timeRecordForm, dateInput)
 	if err != nil {
-		return date, err
+		return date, ErrDateFormat
 	}
 
 	// Ensure date is not future dated
 	today := time.Now()
 	if today.Unix() < date.Unix() {
-		return date, errors.New("Invalid date. Date cannot be future, must be less than current date")
+		return date, ErrDateFuture
 	}
 
 	return date, err
 }
 
-// RequestInput function
+// RequestInput function checks every field of r and returns all the
+// problems found as Errors
 func RequestInput(r *model.RequestInput) (res *model.Request, err error) {
 
 	if r == nil {
-		err = errors.New("Missing RequestInput values")
-		return nil, err
+		return nil, Errors{{Field: bodyField, Error: "is required"}}
 	}
 
 	res = new(model.Request)
+	errs := Errors{}
 
-	res.Date, err = Date(r.Date)
-	if err != nil {
-		return res, err
+	if r.StartDate != "" || r.EndDate != "" {
+		dateRange(r, res, &errs)
+	} else if r.Date == "" {
+		errs.add("date", "is required")
+	} else if res.Date, err = Date(r.Date); err != nil {
+		errs.add("date", err.Error())
+	}
+
+	stations(r, res, &errs)
+	res.Async = r.Async
+	res.Force = r.Force
+	res.NoCharts = r.Charts != nil && !*r.Charts
+	res.Sections = sections(r.Sections, &errs)
+	res.Format = format(r.Format, &errs)
+	if res.Format != "" && (res.StationIDs != nil || res.StationGroup != "") && !model.IsGroupFormat(res.Format) {
+		errs.add("format", fmt.Sprintf("must be %s with stationIDs or stationGroup", strings.Join(model.GroupFormats, " or ")))
+	}
+
+	if r.URLExpiry < 0 {
+		errs.add("urlExpiry", "must be a positive number of minutes")
 	}
+	res.URLExpiry = time.Duration(r.URLExpiry) * time.Minute
 
-	// Little to validate with stationID
+	return res, errs.err()
+}
+
+// dateRange function sets the start and end of a date range request. Date is
+// set to the first month so single month queries still have a date.
+func dateRange(r *model.RequestInput, res *model.Request, errs *Errors) {
+
+	if r.Date != "" {
+		errs.add("date", "must not be set with startDate and endDate")
+	}
+	if r.StartDate == "" {
+		errs.add("startDate", "is required with endDate")
+	}
+	if r.EndDate == "" {
+		errs.add("endDate", "is required with startDate")
+	}
+	if r.StartDate == "" || r.EndDate == "" {
+		return
+	}
+
+	var startErr, endErr error
+	if res.StartDate, startErr = Date(r.StartDate); startErr != nil {
+		errs.add("startDate", startErr.Error())
+	}
+	if res.EndDate, endErr = Date(r.EndDate); endErr != nil {
+		errs.add("endDate", endErr.Error())
+	}
+	if startErr != nil || endErr != nil {
+		return
+	}
+	if res.EndDate.Before(res.StartDate) {
+		errs.add("endDate", "must not be before startDate")
+		return
+	}
+
+	res.Date = time.Date(res.StartDate.Year(), res.StartDate.Month(), 1, 0, 0, 0, 0, res.StartDate.Location())
+	if n := len(res.Months()); n > MaxRangeMonths {
+		errs.add("endDate", fmt.Sprintf("must be within %d months of startDate, the range covers %d", MaxRangeMonths, n))
+	}
+}
+
+// StationGroup function replaces the requested station group with the
+// stations listed for it in groups
+func StationGroup(req *model.Request, groups map[string][]string) (err error) {
+
+	if req.StationGroup == "" {
+		return nil
+	}
+	ids, ok := groups[req.StationGroup]
+	if !ok {
+		return Errors{{Field: "stationGroup", Error: fmt.Sprintf("unknown group %s", req.StationGroup)}}
+	}
+	req.StationIDs = uniqueIDs(ids)
+	if len(req.StationIDs) == 0 {
+		return Errors{{Field: "stationGroup", Error: fmt.Sprintf("group %s has no stations", req.StationGroup)}}
+	}
+	return err
+}
+
+// stations function sets the requested station, stations or station group.
+// Only one may be given and a list of one station is treated as stationID.
+func stations(r *model.RequestInput, res *model.Request, errs *Errors) {
+
+	var given []string
+	if r.StationID != "" {
+		given = append(given, "stationID")
+	}
+	if r.StationIDs != nil {
+		given = append(given, "stationIDs")
+	}
+	if r.StationGroup != "" {
+		given = append(given, "stationGroup")
+	}
+	switch {
+	case len(given) == 0:
+		errs.add("stationID", "is required")
+	case len(given) > 1:
+		errs.add(given[1], "use only one of stationID, stationIDs or stationGroup")
+	}
+
+	if r.StationID != "" && !StationID(r.StationID) {
+		errs.add("stationID", "must be a UUID")
+	}
 	res.StationID = r.StationID
+	res.StationGroup = r.StationGroup
+
+	if r.StationIDs != nil {
+		ids := uniqueIDs(r.StationIDs)
+		for _, id := range ids {
+			if !StationID(id) {
+				errs.add("stationIDs", fmt.Sprintf("must contain only UUIDs, %s is not", id))
+			}
+		}
+		switch len(ids) {
+		case 0:
+			errs.add("stationIDs", "must contain at least one station")
+		case 1:
+			res.StationID = ids[0]
+		default:
+			res.StationIDs = ids
+		}
+	}
+}
+
+// sections function checks each requested section name and drops duplicates.
+// Sheets are written in the order requested. No sections means the whole report.
+func sections(names []string, errs *Errors) (res []string) {
+
+	if names == nil {
+		return nil
+	}
+	if len(names) == 0 {
+		errs.add("sections", "must contain at least one section")
+		return nil
+	}
+
+	known := make(map[string]bool, len(model.Sections))
+	for _, sec := range model.Sections {
+		known[sec] = true
+	}
+
+	var unknown []string
+	seen := make(map[string]bool, len(names))
+	for _, nm := range names {
+		switch {
+		case seen[nm]:
+		case !known[nm]:
+			unknown = append(unknown, nm)
+		default:
+			res = append(res, nm)
+		}
+		seen[nm] = true
+	}
+	if len(unknown) > 0 {
+		errs.add("sections", fmt.Sprintf("unknown section %s, must be one of %s",
+			strings.Join(unknown, ", "), strings.Join(model.Sections, ", ")))
+		return nil
+	}
+	return res
+}
 
-	return res, nil
+// format function checks the requested output format, defaulting to the first of model.Formats
+func format(name string, errs *Errors) string {
+
+	if name == "" {
+		return model.Formats[0]
+	}
+	for _, f := range model.Formats {
+		if strings.EqualFold(name, f) {
+			return f
+		}
+	}
+	errs.add("format", fmt.Sprintf("unknown format %s, must be one of %s", name, strings.Join(model.Formats, ", ")))
+	return ""
+}
+
+// uniqueIDs function trims ids and drops blanks and duplicates, keeping order
+func uniqueIDs(ids []string) (res []string) {
+
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		res = append(res, id)
+	}
+	return res
 }
@@ -2,6 +2,7 @@ package validate
 
 import (
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 const (
 	date       = "2018-05-01"
 	stationID  = "d03224a7-f1df-4863-bcaa-5c6e61af11fc"
+	secondID   = "8a1ec3b1-4d0c-4a3b-9a23-6b1f5d6e2c7a"
 	timeFormat = "2006-01-02"
 )
 
@@ -49,6 +51,227 @@ func (suite *UnitSuite) TestRequestInput() {
 	res, err := RequestInput(req)
 	suite.NoError(err)
 	suite.IsType(&model.Request{}, res)
+	suite.False(res.Async)
+
+	req.Async = true
+	res, err = RequestInput(req)
+	suite.NoError(err)
+	suite.True(res.Async)
+}
+
+// TestRequestInputURLExpiry method
+func (suite *UnitSuite) TestRequestInputURLExpiry() {
+	req := &model.RequestInput{
+		Date:      date,
+		StationID: stationID,
+		URLExpiry: 60,
+	}
+	res, err := RequestInput(req)
+	suite.NoError(err)
+	suite.Equal(time.Hour, res.URLExpiry)
+
+	req.URLExpiry = -1
+	_, err = RequestInput(req)
+	suite.Error(err)
+}
+
+// TestRequestInputDateRange method
+func (suite *UnitSuite) TestRequestInputDateRange() {
+	req := &model.RequestInput{
+		StartDate: "2018-07-15",
+		EndDate:   "2018-09-30",
+		StationID: stationID,
+	}
+	res, err := RequestInput(req)
+	suite.NoError(err)
+	suite.True(res.IsRange())
+	suite.Equal("2018-07-01", res.Date.Format(timeFormat))
+	suite.Len(res.Months(), 3)
+
+	for _, bad := range []struct {
+		in model.RequestInput
+		fe FieldError
+	}{
+		{model.RequestInput{StartDate: "2018-07-15"}, FieldError{"endDate", "is required with startDate"}},
+		{model.RequestInput{StartDate: "2018-09-30", EndDate: "2018-07-15"}, FieldError{"endDate", "must not be before startDate"}},
+		{model.RequestInput{StartDate: "2018-07-15", EndDate: "2018-09-31"}, FieldError{"endDate", "must be YYYY-MM-DD"}},
+		{model.RequestInput{StartDate: "2015-01-01", EndDate: "2018-01-01"}, FieldError{"endDate", "must be within 24 months of startDate, the range covers 37"}},
+		{model.RequestInput{Date: date, StartDate: "2018-07-15", EndDate: "2018-09-30"}, FieldError{"date", "must not be set with startDate and endDate"}},
+	} {
+		bad.in.StationID = stationID
+		_, err = RequestInput(&bad.in)
+		suite.Equal(Errors{bad.fe}, err, bad.in)
+	}
+}
+
+// TestRequestInputErrors method
+func (suite *UnitSuite) TestRequestInputErrors() {
+	_, err := RequestInput(&model.RequestInput{})
+	suite.Equal(Errors{{Field: "date", Error: "is required"}, {Field: "stationID", Error: "is required"}}, err)
+
+	_, err = RequestInput(&model.RequestInput{
+		Date:       "05/01/2018",
+		StationID:  stationID,
+		StationIDs: []string{},
+		Sections:   []string{"deliveries"},
+		Format:     "ods",
+		URLExpiry:  -1,
+	})
+	suite.Require().IsType(Errors{}, err)
+	var fields []string
+	for _, fe := range err.(Errors) {
+		fields = append(fields, fe.Field)
+	}
+	suite.Equal([]string{"date", "stationIDs", "stationIDs", "sections", "format", "urlExpiry"}, fields)
+	suite.Equal(FieldError{Field: "date", Error: "must be YYYY-MM-DD"}, err.(Errors)[0])
+
+	_, err = RequestInput(&model.RequestInput{Date: "2999-01-01", StationID: stationID})
+	suite.EqualError(err, "Invalid request. date must not be in the future")
+}
+
+// TestRequestInputSections method
+func (suite *UnitSuite) TestRequestInputSections() {
+	req := &model.RequestInput{
+		Date:      date,
+		StationID: stationID,
+	}
+	res, err := RequestInput(req)
+	suite.NoError(err)
+	suite.Nil(res.Sections)
+	suite.Equal(model.Sections, res.SectionList())
+
+	req.Sections = []string{model.SectionOverShortMonth, model.SectionFuelDelivery, model.SectionOverShortMonth}
+	res, err = RequestInput(req)
+	suite.NoError(err)
+	suite.Equal([]string{model.SectionOverShortMonth, model.SectionFuelDelivery}, res.Sections)
+	suite.True(res.HasSection(model.SectionFuelDelivery))
+	suite.False(res.HasSection(model.SectionFuelSales))
+
+	req.Sections = []string{model.SectionFuelDelivery, "deliveries"}
+	_, err = RequestInput(req)
+	suite.Equal(Errors{{Field: "sections", Error: "unknown section deliveries, must be one of " +
+		"fuelSales, fuelSalesNL, fuelSalesDSL, fuelDelivery, overShortMonth, overShortAnnual"}}, err)
+
+	req.Sections = []string{}
+	_, err = RequestInput(req)
+	suite.Error(err)
+}
+
+// TestRequestInputFormat method
+func (suite *UnitSuite) TestRequestInputFormat() {
+	req := &model.RequestInput{
+		Date:      date,
+		StationID: stationID,
+	}
+	res, err := RequestInput(req)
+	suite.NoError(err)
+	suite.Equal(model.FormatXLSX, res.Format)
+
+	req.Format = "CSV"
+	res, err = RequestInput(req)
+	suite.NoError(err)
+	suite.Equal(model.FormatCSV, res.Format)
+
+	req.Format = "pdf"
+	req.StationIDs, req.StationID = []string{stationID, secondID}, ""
+	_, err = RequestInput(req)
+	suite.Equal(Errors{{Field: "format", Error: "must be xlsx with stationIDs or stationGroup"}}, err)
+
+	req.StationIDs, req.StationID = nil, stationID
+	req.Format = "ods"
+	_, err = RequestInput(req)
+	suite.Equal(Errors{{Field: "format", Error: "unknown format ods, must be one of xlsx, csv, pdf, json"}}, err)
+}
+
+// TestRequestInputCharts method
+func (suite *UnitSuite) TestRequestInputCharts() {
+	req := &model.RequestInput{
+		Date:      date,
+		StationID: stationID,
+	}
+	res, err := RequestInput(req)
+	suite.NoError(err)
+	suite.False(res.NoCharts, "Expected charts by default")
+
+	on, off := true, false
+	req.Charts = &on
+	res, _ = RequestInput(req)
+	suite.False(res.NoCharts)
+
+	req.Charts = &off
+	res, _ = RequestInput(req)
+	suite.True(res.NoCharts)
+}
+
+// TestRequestInputStationIDs method
+func (suite *UnitSuite) TestRequestInputStationIDs() {
+	req := &model.RequestInput{
+		Date:       date,
+		StationIDs: []string{stationID, " " + secondID + " ", stationID, ""},
+	}
+	res, err := RequestInput(req)
+	suite.NoError(err)
+	suite.Equal([]string{stationID, secondID}, res.StationIDs)
+	suite.Equal("", res.StationID)
+
+	req.StationIDs = []string{stationID, stationID}
+	res, err = RequestInput(req)
+	suite.NoError(err)
+	suite.Equal(stationID, res.StationID)
+	suite.Nil(res.StationIDs)
+
+	req.StationIDs = []string{}
+	_, err = RequestInput(req)
+	suite.Error(err)
+
+	req.StationIDs = []string{stationID, "second", "1234"}
+	_, err = RequestInput(req)
+	suite.Equal(Errors{
+		{Field: "stationIDs", Error: "must contain only UUIDs, second is not"},
+		{Field: "stationIDs", Error: "must contain only UUIDs, 1234 is not"},
+	}, err)
+
+	req.StationIDs = []string{stationID}
+	req.StationID = stationID
+	_, err = RequestInput(req)
+	suite.Error(err)
+}
+
+// TestRequestInputStationID method
+func (suite *UnitSuite) TestRequestInputStationID() {
+	for _, id := range []string{stationID, strings.ToUpper(secondID)} {
+		res, err := RequestInput(&model.RequestInput{Date: date, StationID: id})
+		suite.NoError(err, id)
+		suite.Equal(id, res.StationID)
+	}
+	for _, id := range []string{"1234", "d03224a7f1df4863bcaa5c6e61af11fc", "d03224a7-f1df-4863-bcaa-5c6e61af11fz", " " + stationID} {
+		_, err := RequestInput(&model.RequestInput{Date: date, StationID: id})
+		suite.Equal(Errors{{Field: "stationID", Error: "must be a UUID"}}, err, id)
+	}
+}
+
+// TestStationGroup method
+func (suite *UnitSuite) TestStationGroup() {
+	groups := map[string][]string{
+		"east":  {stationID, secondID},
+		"empty": {},
+	}
+	res, err := RequestInput(&model.RequestInput{Date: date, StationGroup: "east"})
+	suite.NoError(err)
+	suite.NoError(StationGroup(res, groups))
+	suite.Equal([]string{stationID, secondID}, res.StationIDs)
+
+	res.StationGroup = "west"
+	suite.Error(StationGroup(res, groups))
+
+	res.StationGroup = "empty"
+	suite.Error(StationGroup(res, groups))
+
+	res.StationGroup = "west"
+	suite.Equal(Errors{{Field: "stationGroup", Error: "unknown group west"}}, StationGroup(res, groups))
+
+	_, err = RequestInput(&model.RequestInput{Date: date, StationID: stationID, StationGroup: "east"})
+	suite.Equal(Errors{{Field: "stationGroup", Error: "use only one of stationID, stationIDs or stationGroup"}}, err)
 }
 
 // TestUnitSuite function
@@ -0,0 +1,102 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+
+	"github.com/pulpfree/gdps-fs-dwnld/model"
+)
+
+// stationIDPattern matches a station ID, a UUID in canonical form
+var stationIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// StationID function reports whether id is well formed
+func StationID(id string) bool {
+	return stationIDPattern.MatchString(id)
+}
+
+// StationFinder interface looks up a station, see graphql.Client
+type StationFinder interface {
+	Station(ctx context.Context, id string) (*model.Station, error)
+}
+
+// StationCache struct remembers the stations found, so a warm lambda looks
+// each up once. Unknown stations aren't kept as they may yet be added.
+type StationCache struct {
+	mu    sync.Mutex
+	known map[string]bool
+}
+
+// NewStationCache function
+func NewStationCache() *StationCache {
+	return &StationCache{known: make(map[string]bool)}
+}
+
+// Stations method checks that each station of req exists, looking up at most
+// limit stations at once. The finder's error for the first listed station that
+// doesn't is returned, a graphql.NotFoundError for an unknown station. The
+// remaining lookups are cancelled once one fails.
+func (c *StationCache) Stations(ctx context.Context, req *model.Request, finder StationFinder, limit int) error {
+
+	ids := req.StationList()
+	if limit < 1 {
+		limit = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(ids))
+	sem := make(chan struct{}, limit)
+
+	for i, id := range ids {
+		if c.has(id) {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+		case sem <- struct{}{}:
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if _, err := finder.Station(ctx, id); err != nil {
+				errs[i] = fmt.Errorf("Invalid stationID %s: %w", id, err)
+				cancel()
+				return
+			}
+			c.add(id)
+		}(i, id)
+	}
+	wg.Wait()
+
+	// Lookups cancelled by another's failure report that failure instead
+	for _, err := range errs {
+		if err != nil && !errors.Is(err, context.Canceled) {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+func (c *StationCache) has(id string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.known[id]
+}
+
+func (c *StationCache) add(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.known[id] = true
+}
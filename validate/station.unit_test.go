@@ -0,0 +1,97 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/pulpfree/gdps-fs-dwnld/graphql"
+	"github.com/pulpfree/gdps-fs-dwnld/model"
+)
+
+// stationFinder struct knows the stations listed and counts lookups, and the
+// most run at once
+type stationFinder struct {
+	stations map[string]bool
+	err      error
+	delay    time.Duration
+
+	mu      sync.Mutex
+	lookups int
+	running int
+	most    int
+}
+
+func (f *stationFinder) Station(ctx context.Context, id string) (*model.Station, error) {
+	f.mu.Lock()
+	f.lookups++
+	f.running++
+	if f.running > f.most {
+		f.most = f.running
+	}
+	f.mu.Unlock()
+	defer func() {
+		f.mu.Lock()
+		f.running--
+		f.mu.Unlock()
+	}()
+
+	time.Sleep(f.delay)
+	if f.err != nil {
+		return nil, f.err
+	}
+	if !f.stations[id] {
+		return nil, &graphql.NotFoundError{Section: "Station", Err: errors.New("station " + id + " not found")}
+	}
+	return &model.Station{ID: id, Name: "Test Station"}, nil
+}
+
+// TestStationCache method
+func (suite *UnitSuite) TestStationCache() {
+	ctx := context.Background()
+	finder := &stationFinder{stations: map[string]bool{stationID: true, secondID: true}}
+	cache := NewStationCache()
+
+	suite.NoError(cache.Stations(ctx, &model.Request{StationID: stationID}, finder, 1))
+	suite.NoError(cache.Stations(ctx, &model.Request{StationIDs: []string{stationID, secondID}}, finder, 2))
+	suite.Equal(2, finder.lookups, "Expected each station looked up once")
+
+	unknown := "4f5b7d7e-9a3c-4d5e-8f1a-2b3c4d5e6f70"
+	err := cache.Stations(ctx, &model.Request{StationIDs: []string{stationID, unknown}}, finder, 2)
+	var nfErr *graphql.NotFoundError
+	suite.True(errors.As(err, &nfErr), "Expected NotFoundError")
+	suite.Contains(err.Error(), unknown)
+
+	suite.Error(cache.Stations(ctx, &model.Request{StationID: unknown}, finder, 2))
+	suite.Equal(4, finder.lookups, "Expected unknown stations not to be cached")
+
+	finder.err = errors.New("graphql unavailable")
+	suite.NoError(cache.Stations(ctx, &model.Request{StationID: secondID}, finder, 2), "Expected a cached station without a lookup")
+	suite.Error(cache.Stations(ctx, &model.Request{StationID: unknown}, finder, 2))
+}
+
+// TestStationsConcurrency method
+func (suite *UnitSuite) TestStationsConcurrency() {
+	ctx := context.Background()
+	ids := []string{
+		"4f5b7d7e-9a3c-4d5e-8f1a-2b3c4d5e6f71",
+		"4f5b7d7e-9a3c-4d5e-8f1a-2b3c4d5e6f72",
+		"4f5b7d7e-9a3c-4d5e-8f1a-2b3c4d5e6f73",
+		"4f5b7d7e-9a3c-4d5e-8f1a-2b3c4d5e6f74",
+		"4f5b7d7e-9a3c-4d5e-8f1a-2b3c4d5e6f75",
+	}
+	finder := &stationFinder{stations: make(map[string]bool), delay: 10 * time.Millisecond}
+	for _, id := range ids {
+		finder.stations[id] = true
+	}
+
+	suite.NoError(NewStationCache().Stations(ctx, &model.Request{StationIDs: ids}, finder, 2))
+	suite.Equal(len(ids), finder.lookups)
+	suite.Equal(2, finder.most, "Expected lookups limited to 2 at once")
+
+	delete(finder.stations, ids[3])
+	err := NewStationCache().Stations(ctx, &model.Request{StationIDs: ids}, finder, 3)
+	suite.Error(err)
+	suite.Contains(err.Error(), ids[3])
+}
@@ -0,0 +1,123 @@
+package xlsx
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Chart sizes in pixels, widened or lengthened to suit the number of categories
+const (
+	chartWidth       = 480
+	chartHeight      = 290
+	chartCategoryPix = 20
+)
+
+// chart struct describes a chart of a sheet's fuel type columns. Series names
+// are taken from headerRow, categories from column A of firstRow to lastRow.
+type chart struct {
+	kind      string // excelize chart type, e.g. line, bar or col
+	title     string
+	sheet     string
+	cell      string // top left corner of the chart
+	headerRow int
+	firstRow  int
+	lastRow   int
+	firstCol  int
+	lastCol   int
+}
+
+// chartFormat struct is the subset of the excelize AddChart format used here
+type chartFormat struct {
+	Type      string        `json:"type"`
+	Series    []chartSeries `json:"series"`
+	Dimension struct {
+		Width  int `json:"width"`
+		Height int `json:"height"`
+	} `json:"dimension"`
+	Format struct {
+		XOffset  int  `json:"x_offset"`
+		YOffset  int  `json:"y_offset"`
+		PrintObj bool `json:"print_obj"`
+	} `json:"format"`
+	Legend struct {
+		Position string `json:"position"`
+	} `json:"legend"`
+	Title struct {
+		Name string `json:"name"`
+	} `json:"title"`
+	ShowBlanksAs string `json:"show_blanks_as"`
+}
+
+// chartSeries struct
+type chartSeries struct {
+	Name       string `json:"name"`
+	Categories string `json:"categories"`
+	Values     string `json:"values"`
+}
+
+// SetCharts method turns the charts added to the sales and over-short
+// sheets on or off, they are on by default
+func (x *XLSX) SetCharts(on bool) {
+	x.charts = on
+}
+
+// addChart method adds c to its sheet, with a series for each column. Sheets
+// without data rows are left without a chart.
+func (x *XLSX) addChart(c chart) (err error) {
+
+	if !x.charts || c.lastRow < c.firstRow || c.lastCol < c.firstCol {
+		return nil
+	}
+
+	var f chartFormat
+	f.Type = c.kind
+	f.Title.Name = c.title
+	f.Legend.Position = "bottom"
+	f.ShowBlanksAs = "gap"
+	f.Format.XOffset = 15
+	f.Format.PrintObj = true
+
+	// Horizontal bars need the height, the other types the width, to fit each category
+	categories := c.lastRow - c.firstRow + 1
+	f.Dimension.Width, f.Dimension.Height = chartWidth, chartHeight
+	if size := categories * chartCategoryPix; c.kind == "bar" && size > chartHeight {
+		f.Dimension.Height = size
+	} else if c.kind != "bar" && size > chartWidth {
+		f.Dimension.Width = size
+	}
+
+	for col := c.firstCol; col <= c.lastCol; col++ {
+		f.Series = append(f.Series, chartSeries{
+			Name:       sheetRef(c.sheet, absRange(col, c.headerRow, col, c.headerRow)),
+			Categories: sheetRef(c.sheet, absRange(1, c.firstRow, 1, c.lastRow)),
+			Values:     sheetRef(c.sheet, absRange(col, c.firstRow, col, c.lastRow)),
+		})
+	}
+
+	format, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	if err = x.file.AddChart(c.sheet, c.cell, string(format)); err != nil {
+		log.Errorf("Error adding %s chart to %s: %s", c.kind, c.sheet, err)
+	}
+	return err
+}
+
+// absRange function returns the absolute A1 style reference of the cells
+// between the two corners, e.g. $B$3:$B$33, or of a single cell
+func absRange(startCol, startRow, endCol, endRow int) string {
+	start := "$" + columnName(startCol) + "$" + strconv.Itoa(startRow)
+	if startCol == endCol && startRow == endRow {
+		return start
+	}
+	return start + ":$" + columnName(endCol) + "$" + strconv.Itoa(endRow)
+}
+
+// sheetRef function qualifies ref with the quoted sheet name, e.g. 'Fuel Sales'!$B$3
+func sheetRef(sheet, ref string) string {
+	return "'" + strings.Replace(sheet, "'", "''", -1) + "'!" + ref
+}
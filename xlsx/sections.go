@@ -0,0 +1,72 @@
+package xlsx
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/pulpfree/gdps-fs-dwnld/model"
+)
+
+// RenderFunc writes a section to a new sheet named sheetTitle
+type RenderFunc func(x *XLSX, sheetTitle string, sr *model.StationReport) error
+
+var (
+	rendersMu sync.RWMutex
+	renders   = make(map[string]RenderFunc)
+)
+
+// RegisterSection function sets how the section called name, see
+// model.RegisterSection, is written to a workbook. It replaces any render
+// registered for the same name.
+func RegisterSection(name string, render RenderFunc) {
+	rendersMu.Lock()
+	defer rendersMu.Unlock()
+	renders[name] = render
+}
+
+// lookupRender function returns the render registered for the section called name
+func lookupRender(name string) (render RenderFunc, ok bool) {
+	rendersMu.RLock()
+	defer rendersMu.RUnlock()
+	render, ok = renders[name]
+	return render, ok
+}
+
+// WriteSection method renders the section called name from sr to a new
+// sheet, named sheetTitle or, if that's empty, the section title
+func (x *XLSX) WriteSection(name, sheetTitle string, sr *model.StationReport) (err error) {
+
+	s, err := model.SectionFor(name, sr)
+	if err != nil {
+		return err
+	}
+	render, ok := lookupRender(name)
+	if !ok {
+		return fmt.Errorf("No workbook layout for report section %s", name)
+	}
+	if sheetTitle == "" {
+		sheetTitle = s.Title
+	}
+	return render(x, sheetTitle, sr)
+}
+
+func init() {
+	RegisterSection(model.SectionFuelSales, func(x *XLSX, sheetTitle string, sr *model.StationReport) error {
+		return x.FuelSalesSheet(sheetTitle, sr.FuelSales)
+	})
+	RegisterSection(model.SectionFuelSalesNL, func(x *XLSX, sheetTitle string, sr *model.StationReport) error {
+		return x.FuelSalesListNLSheet(sheetTitle, sr.FuelSalesList)
+	})
+	RegisterSection(model.SectionFuelSalesDSL, func(x *XLSX, sheetTitle string, sr *model.StationReport) error {
+		return x.FuelSalesListDSLSheet(sheetTitle, sr.FuelSalesList)
+	})
+	RegisterSection(model.SectionFuelDelivery, func(x *XLSX, sheetTitle string, sr *model.StationReport) error {
+		return x.FuelDeliverySheet(sheetTitle, sr.FuelDelivery)
+	})
+	RegisterSection(model.SectionOverShortMonth, func(x *XLSX, sheetTitle string, sr *model.StationReport) error {
+		return x.OverShortMonthSheet(sheetTitle, sr.OverShortMonth)
+	})
+	RegisterSection(model.SectionOverShortAnnual, func(x *XLSX, sheetTitle string, sr *model.StationReport) error {
+		return x.OverShortAnnualSheet(sheetTitle, sr.OverShortAnnual)
+	})
+}
@@ -0,0 +1,178 @@
+package xlsx
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pulpfree/gdps-fs-dwnld/model"
+)
+
+// summarySection struct describes one block of the group summary sheet.
+// values returns the station's section report, whose sheet totals the summary
+// refers to, and its API summary, or false when the station report doesn't
+// include the section.
+type summarySection struct {
+	heading string
+	values  func(sr *model.StationReport) (interface{}, map[string]float64, bool)
+}
+
+var summarySections = []summarySection{
+	{"Fuel Sales", func(sr *model.StationReport) (interface{}, map[string]float64, bool) {
+		if sr.FuelSales == nil {
+			return nil, nil, false
+		}
+		return sr.FuelSales, sr.FuelSales.Report.SalesSummary, true
+	}},
+	{"Fuel Deliveries", func(sr *model.StationReport) (interface{}, map[string]float64, bool) {
+		if sr.FuelDelivery == nil {
+			return nil, nil, false
+		}
+		return sr.FuelDelivery, sr.FuelDelivery.Report.DeliverySummary, true
+	}},
+	{"Over-Short", func(sr *model.StationReport) (interface{}, map[string]float64, bool) {
+		if sr.OverShortMonth == nil {
+			return nil, nil, false
+		}
+		return sr.OverShortMonth, sr.OverShortMonth.Report.OverShortSummary, true
+	}},
+}
+
+// GroupSummary method writes a sheet totalling sales, deliveries and over-short
+// by fuel type for each station in srs, with a group total for each section.
+// Sections missing from the station reports are left out. Each station's
+// figures refer to the totals on its own sheets once written, see linkSummary.
+func (x *XLSX) GroupSummary(groupName string, srs []*model.StationReport) (err error) {
+
+	if len(srs) == 0 {
+		return errors.New("Missing station reports for group summary")
+	}
+	first := summaryReport(srs[0])
+	if first == nil {
+		return errors.New("Group summary requires fuel sales, delivery or over-short month reports")
+	}
+
+	var cell string
+	var style int
+
+	xlsx := x.file
+	sheetNm := x.addSheet("Summary")
+	fuelTypes := groupFuelTypes(srs)
+	totalCol := len(fuelTypes) + 2
+
+	endCell := cellName(totalCol, 1)
+	xlsx.MergeCell(sheetNm, "A1", endCell)
+
+	style, _ = xlsx.NewStyle(`{"font":{"bold":true,"size":12}}`)
+	title := fmt.Sprintf("%s Summary - %s", groupName, model.PeriodTitle(first.date, first.endDate))
+	xlsx.SetCellValue(sheetNm, "A1", title)
+	xlsx.SetCellStyle(sheetNm, "A1", "A1", style)
+
+	xlsx.SetColWidth(sheetNm, "A", "A", 30)
+	xlsx.SetColWidth(sheetNm, "B", columnName(totalCol), 12)
+
+	headStyle, _ := xlsx.NewStyle(`{"font":{"bold":true}}`)
+	numStyle, _ := xlsx.NewStyle(`{"number_format": 3}`)
+	totalStyle, _ := xlsx.NewStyle(`{"number_format": 3, "font":{"bold":true}}`)
+
+	row := 3
+	for _, sec := range summarySections {
+		if _, _, ok := sec.values(srs[0]); !ok {
+			continue
+		}
+
+		// Section heading with fuel type columns
+		cell = cellName(1, row)
+		xlsx.SetCellValue(sheetNm, cell, sec.heading)
+		xlsx.SetCellStyle(sheetNm, cell, cell, style)
+		col := 2
+		for _, ft := range fuelTypes {
+			cell = cellName(col, row)
+			xlsx.SetCellValue(sheetNm, cell, ft)
+			xlsx.SetCellStyle(sheetNm, cell, cell, headStyle)
+			col++
+		}
+		cell = cellName(col, row)
+		xlsx.SetCellValue(sheetNm, cell, "Total")
+		xlsx.SetCellStyle(sheetNm, cell, cell, headStyle)
+		row++
+
+		// One row per station
+		firstRow := row
+		for _, sr := range srs {
+			report, values, _ := sec.values(sr)
+
+			xlsx.SetCellValue(sheetNm, cellName(1, row), summaryReport(sr).station)
+			col = 2
+			for _, ft := range fuelTypes {
+				cell = cellName(col, row)
+				xlsx.SetCellValue(sheetNm, cell, values[ft])
+				xlsx.SetCellStyle(sheetNm, cell, cell, numStyle)
+				x.summary = append(x.summary, summaryLink{sheetNm, cell, report, ft, values[ft]})
+				col++
+			}
+			x.setSum(sheetNm, cellName(col, row), cellRange(2, row, col-1, row), totalStyle)
+			row++
+		}
+
+		// Group totals
+		cell = cellName(1, row)
+		xlsx.SetCellValue(sheetNm, cell, "Group Total")
+		xlsx.SetCellStyle(sheetNm, cell, cell, headStyle)
+		for col = 2; col <= totalCol; col++ {
+			x.setSum(sheetNm, cellName(col, row), cellRange(col, firstRow, col, row-1), totalStyle)
+		}
+
+		row += 2
+	}
+
+	return err
+}
+
+// summaryInfo struct holds the details the summary needs from whichever
+// section a station report includes
+type summaryInfo struct {
+	station       string
+	date, endDate time.Time
+}
+
+// summaryReport function returns the summary details of sr, or nil when sr
+// has none of the summarised sections
+func summaryReport(sr *model.StationReport) *summaryInfo {
+	switch {
+	case sr.FuelSales != nil:
+		return &summaryInfo{sr.FuelSales.Station.Name, sr.FuelSales.Date, sr.FuelSales.EndDate}
+	case sr.FuelDelivery != nil:
+		return &summaryInfo{sr.FuelDelivery.Station.Name, sr.FuelDelivery.Date, sr.FuelDelivery.EndDate}
+	case sr.OverShortMonth != nil:
+		return &summaryInfo{sr.OverShortMonth.Station.Name, sr.OverShortMonth.Date, sr.OverShortMonth.EndDate}
+	}
+	return nil
+}
+
+// groupFuelTypes function returns every fuel type reported by any station in srs
+func groupFuelTypes(srs []*model.StationReport) []string {
+
+	var fts []string
+	seen := make(map[string]bool)
+	add := func(types []string) {
+		for _, ft := range types {
+			if !seen[ft] {
+				seen[ft] = true
+				fts = append(fts, ft)
+			}
+		}
+	}
+	for _, sr := range srs {
+		if sr.FuelSales != nil {
+			add(sr.FuelSales.Report.FuelTypes)
+		}
+		if sr.FuelDelivery != nil {
+			add(sr.FuelDelivery.Report.FuelTypes)
+		}
+		if sr.OverShortMonth != nil {
+			add(sr.OverShortMonth.Report.FuelTypes)
+		}
+	}
+	return model.SortFuelTypes(fts)
+}
@@ -0,0 +1,79 @@
+package xlsx
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// totalTolerance is the largest difference between the sum of a sheet's rows
+// and the API summary that isn't reported, it allows for rounding in the API
+const totalTolerance = 0.01
+
+// totalAuthor is the author of the comments added to mismatched totals
+const totalAuthor = "Report check: "
+
+// setSum method writes a formula totalling rng to cell, so that the total
+// follows any correction made to the rows
+func (x *XLSX) setSum(sheet, cell, rng string, style int) {
+	x.file.SetCellFormula(sheet, cell, fmt.Sprintf("SUM(%s)", rng))
+	x.file.SetCellStyle(sheet, cell, cell, style)
+}
+
+// checkTotal method compares sum, the total of the rows written for cell, to
+// the summary supplied by the API. A mismatch is logged and commented on the cell.
+func (x *XLSX) checkTotal(sheet, cell string, sum, api float64) {
+
+	if math.Abs(sum-api) <= totalTolerance {
+		return
+	}
+	log.Warnf("Total in %s!%s of %.2f doesn't match the report summary of %.2f", sheet, cell, sum, api)
+
+	format, _ := json.Marshal(map[string]string{
+		"author": totalAuthor,
+		"text":   fmt.Sprintf("The rows total %.2f, the report summary is %.2f", sum, api),
+	})
+	if err := x.file.AddComment(sheet, cell, string(format)); err != nil {
+		log.Errorf("Error adding comment to %s!%s: %s", sheet, cell, err)
+	}
+}
+
+// sheetTotal struct is a fuel type total written to a section sheet
+type sheetTotal struct {
+	ref string  // the total's cell, see sheetRef
+	sum float64 // total of the rows
+}
+
+// summaryLink struct is a group summary cell showing a station's section total
+type summaryLink struct {
+	sheet, cell string
+	report      interface{} // the station's section report, e.g. *model.FuelSales
+	fuelType    string
+	api         float64 // the API summary for the fuel type
+}
+
+// recordTotal method notes that the fuelType total of report, summing to sum,
+// was written to cell so that the group summary can refer to it
+func (x *XLSX) recordTotal(report interface{}, fuelType, sheet, cell string, sum float64) {
+	if x.totals[report] == nil {
+		x.totals[report] = make(map[string]sheetTotal)
+	}
+	x.totals[report][fuelType] = sheetTotal{ref: sheetRef(sheet, cell), sum: sum}
+}
+
+// linkSummary method points each group summary cell at the station sheet
+// total it shows. The summary is written before the station sheets, so this
+// waits for the output. Cells without a station sheet total keep the API summary.
+func (x *XLSX) linkSummary() {
+	for _, l := range x.summary {
+		t, ok := x.totals[l.report][l.fuelType]
+		if !ok {
+			continue
+		}
+		x.file.SetCellFormula(l.sheet, l.cell, t.ref)
+		x.checkTotal(l.sheet, l.cell, t.sum, l.api)
+	}
+	x.summary = nil
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"math"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -16,12 +17,21 @@ import (
 
 // XLSX struct
 type XLSX struct {
-	file *excelize.File
+	file    *excelize.File
+	sheets  map[string]bool // lower cased names of the sheets written so far
+	charts  bool
+	totals  map[interface{}]map[string]sheetTotal // fuel type totals of each section report, see recordTotal
+	summary []summaryLink                         // group summary cells showing those totals, see linkSummary
 }
 
+// ContentType of the generated workbook
+const ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
 // Defaults
 const (
-	abc             = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	defaultSheet    = "Sheet1"
+	maxSheetName    = 31
+	maxColumns      = 16384
 	floatFrmt       = "#,#0"
 	timeShortForm   = "20060102"
 	timeMonthForm   = "200601"
@@ -34,6 +44,9 @@ func NewFile() (x *XLSX, err error) {
 
 	x = new(XLSX)
 	x.file = excelize.NewFile()
+	x.sheets = make(map[string]bool)
+	x.totals = make(map[interface{}]map[string]sheetTotal)
+	x.charts = true
 	if err != nil {
 		log.Errorf("xlsx err %s: ", err)
 	}
@@ -42,24 +55,27 @@ func NewFile() (x *XLSX, err error) {
 
 // FuelSales method
 func (x *XLSX) FuelSales(fs *model.FuelSales) (err error) {
+	return x.FuelSalesSheet("Fuel Sales", fs)
+}
+
+// FuelSalesSheet method writes the FuelSales report to a new sheet named sheetTitle
+func (x *XLSX) FuelSalesSheet(sheetTitle string, fs *model.FuelSales) (err error) {
 
 	var cell string
 	var style int
 
 	xlsx := x.file
-	sheetNm := "Sheet1"
+	sheetNm := x.addSheet(sheetTitle)
 
 	fuelTypes := fs.Report.FuelTypes
 
-	xlsx.SetSheetName(sheetNm, "Fuel Sales")
-
 	// Merge cells to accommodate width of all fuel types
-	endCell := toChar(len(fuelTypes)+3) + "1"
+	endCell := cellName(len(fuelTypes)+3, 1)
 	xlsx.MergeCell(sheetNm, "A1", endCell)
 
 	style, _ = xlsx.NewStyle(`{"font":{"bold":true,"size":12}}`)
 
-	title := fmt.Sprintf("%s Fuel Sales Detail - %s", fs.Station.Name, fs.Date.Format("January 2006"))
+	title := fmt.Sprintf("%s Fuel Sales Detail - %s", fs.Station.Name, model.PeriodTitle(fs.Date, fs.EndDate))
 	xlsx.SetCellValue(sheetNm, "A1", title)
 	xlsx.SetCellStyle(sheetNm, "A1", "A1", style)
 
@@ -71,7 +87,7 @@ func (x *XLSX) FuelSales(fs *model.FuelSales) (err error) {
 	row := 2
 	style, _ = xlsx.NewStyle(`{"font":{"bold":true}}`)
 	for _, ft := range fuelTypes {
-		cell = toChar(col) + strconv.Itoa(row)
+		cell = cellName(col, row)
 		xlsx.SetCellValue(sheetNm, cell, ft)
 		xlsx.SetCellStyle(sheetNm, cell, cell, style)
 		col++
@@ -81,74 +97,94 @@ func (x *XLSX) FuelSales(fs *model.FuelSales) (err error) {
 	col = 1
 	row = 3
 	style, _ = xlsx.NewStyle(`{"number_format": 3}`)
+	sums := make(map[string]float64, len(fuelTypes))
 
 	for _, r := range fs.Report.StationSales {
 
 		t, _ := time.Parse(timeShortForm, strconv.Itoa(int(r.Date)))
-		cell = toChar(col) + strconv.Itoa(row)
+		cell = cellName(col, row)
 		xlsx.SetCellValue(sheetNm, cell, t.Format(dateDayFormat))
 		col++
 
 		for _, ft := range fuelTypes {
-			cell = toChar(col) + strconv.Itoa(row)
+			cell = cellName(col, row)
 			xlsx.SetCellValue(sheetNm, cell, r.Sales[ft])
 			xlsx.SetCellStyle(sheetNm, cell, cell, style)
+			sums[ft] += r.Sales[ft]
 			col++
 		}
 		col = 1
 		row++
 	}
 
+	lastRow := row - 1
+
 	// Fueltype summary
 	style, _ = xlsx.NewStyle(`{"number_format": 3, "font":{"bold":true}}`)
-	cell = toChar(col) + strconv.Itoa(row)
+	cell = cellName(col, row)
 	xlsx.SetCellValue(sheetNm, cell, "")
 	col++
 
+	var total float64
 	for _, ft := range fuelTypes {
-		cell = toChar(col) + strconv.Itoa(row)
-		xlsx.SetCellValue(sheetNm, cell, fs.Report.SalesSummary[ft])
-		xlsx.SetCellStyle(sheetNm, cell, cell, style)
+		cell = cellName(col, row)
+		x.setSum(sheetNm, cell, cellRange(col, 3, col, lastRow), style)
+		x.checkTotal(sheetNm, cell, sums[ft], fs.Report.SalesSummary[ft])
+		x.recordTotal(fs, ft, sheetNm, cell, sums[ft])
+		total += sums[ft]
 		col++
 	}
+	summaryRow := row
 	row += 2
 	col = 1
-	cell = toChar(col) + strconv.Itoa(row)
-	cellNext := toChar(col+1) + strconv.Itoa(row)
+	cell = cellName(col, row)
+	cellNext := cellName(col+1, row)
 	xlsx.MergeCell(sheetNm, cell, cellNext)
 	xlsx.SetCellValue(sheetNm, cell, "Total Sales")
 	xlsx.SetCellStyle(sheetNm, cell, cell, style)
 
 	col += 2
-	cell = toChar(col) + strconv.Itoa(row)
-	cellNext = toChar(col+1) + strconv.Itoa(row)
+	cell = cellName(col, row)
+	cellNext = cellName(col+1, row)
 	xlsx.MergeCell(sheetNm, cell, cellNext)
-	xlsx.SetCellValue(sheetNm, cell, fs.Report.SalesTotal)
-	xlsx.SetCellStyle(sheetNm, cell, cell, style)
-
-	return err
+	x.setSum(sheetNm, cell, cellRange(2, summaryRow, len(fuelTypes)+1, summaryRow), style)
+	x.checkTotal(sheetNm, cell, total, fs.Report.SalesTotal)
+
+	return x.addChart(chart{
+		kind:      "line",
+		title:     "Daily Sales",
+		sheet:     sheetNm,
+		cell:      cellName(len(fuelTypes)+3, 2),
+		headerRow: 2,
+		firstRow:  3,
+		lastRow:   lastRow,
+		firstCol:  2,
+		lastCol:   len(fuelTypes) + 1,
+	})
 }
 
 // FuelSalesListNL method
 func (x *XLSX) FuelSalesListNL(fsl *model.FuelSalesList) (err error) {
+	return x.FuelSalesListNLSheet("No-Lead Fuel Sales by Station", fsl)
+}
+
+// FuelSalesListNLSheet method writes the FuelSalesListNL report to a new sheet named sheetTitle
+func (x *XLSX) FuelSalesListNLSheet(sheetTitle string, fsl *model.FuelSalesList) (err error) {
 
 	var cell string
 	var style int
 
 	wkColWidth := 10.50
 	xlsx := x.file
-	sheetNm := "Sheet2"
-
-	_ = xlsx.NewSheet(sheetNm)
-	xlsx.SetSheetName(sheetNm, "No-Lead Fuel Sales by Station")
+	sheetNm := x.addSheet(sheetTitle)
 
 	// Merge cells to accommodate title
 	startCell := "A1"
-	endCell := toChar(6) + "1"
+	endCell := cellName(6, 1)
 	xlsx.MergeCell(sheetNm, startCell, endCell)
 
 	style, _ = xlsx.NewStyle(`{"font":{"bold":true,"size":12}}`)
-	title := fmt.Sprintf("No-Lead Fuel Sales by Station - %s", fsl.Date.Format("January 2006"))
+	title := fmt.Sprintf("No-Lead Fuel Sales by Station - %s", model.PeriodTitle(fsl.Date, fsl.EndDate))
 	xlsx.SetCellValue(sheetNm, startCell, title)
 	xlsx.SetCellStyle(sheetNm, startCell, endCell, style)
 
@@ -156,16 +192,16 @@ func (x *XLSX) FuelSalesListNL(fsl *model.FuelSalesList) (err error) {
 	col := 2
 	row := 2
 	periodLen := len(fsl.Report.PeriodHeader)
-	xlsx.SetColWidth(sheetNm, "B", toChar((periodLen*2)+2), wkColWidth)
+	xlsx.SetColWidth(sheetNm, "B", columnName((periodLen*2)+2), wkColWidth)
 
-	startCell = toChar(col) + strconv.Itoa(row)
-	endCell = toChar(col+periodLen) + strconv.Itoa(row)
+	startCell = cellName(col, row)
+	endCell = cellName(col+periodLen, row)
 	style, _ = xlsx.NewStyle(`{"font":{"color": "#333333"}}`)
 	xlsx.SetCellStyle(sheetNm, startCell, endCell, style)
 
 	for _, per := range fsl.Report.PeriodHeader {
-		cell = toChar(col) + strconv.Itoa(row)
-		cellNext := toChar(col+1) + strconv.Itoa(row)
+		cell = cellName(col, row)
+		cellNext := cellName(col+1, row)
 		xlsx.MergeCell(sheetNm, cell, cellNext)
 
 		cellVal := fmt.Sprintf("%s/%s", per.StartDate, per.EndDate)
@@ -175,7 +211,7 @@ func (x *XLSX) FuelSalesListNL(fsl *model.FuelSalesList) (err error) {
 
 	// Set the first and last column width
 	xlsx.SetColWidth(sheetNm, "A", "A", 11.00)
-	lastCol := toChar(periodLen + 2)
+	lastCol := columnName(periodLen + 2)
 	xlsx.SetColWidth(sheetNm, lastCol, lastCol, 14.00)
 
 	// Now we can populate
@@ -183,33 +219,33 @@ func (x *XLSX) FuelSalesListNL(fsl *model.FuelSalesList) (err error) {
 	row = 3
 	styleSale, _ := xlsx.NewStyle(`{"number_format": 3}`)
 	styleFuelPrice, _ := xlsx.NewStyle(`{"number_format": 4}`)
-	saleCols := make([]string, periodLen)
+	saleCols := make([]int, periodLen)
 
 	for sc, sales := range fsl.Report.PeriodSales {
-		cell = toChar(col) + strconv.Itoa(row)
+		cell = cellName(col, row)
 		xlsx.SetCellValue(sheetNm, cell, sales.StationName)
 		col++
 
 		// Loop through weeks
 		saleCells := make([]string, periodLen)
 		for i, ps := range fsl.Report.PeriodHeader {
-			cell = toChar(col) + strconv.Itoa(row)
+			cell = cellName(col, row)
 			xlsx.SetCellValue(sheetNm, cell, sales.Periods[i].FuelSales["NL"])
 			xlsx.SetCellStyle(sheetNm, cell, cell, styleSale)
 			saleCells[i] = cell
 			if sc == 0 {
-				saleCols[i] = toChar(col)
+				saleCols[i] = col
 			}
 			col++
 
-			cell = toChar(col) + strconv.Itoa(row)
+			cell = cellName(col, row)
 			xlsx.SetCellValue(sheetNm, cell, sales.FuelPrices.Prices[ps.YearWeek])
 			xlsx.SetCellStyle(sheetNm, cell, cell, styleFuelPrice)
 			col++
 		}
 
 		// Station summary cell
-		cell = toChar(col) + strconv.Itoa(row)
+		cell = cellName(col, row)
 		xlsx.SetCellStyle(sheetNm, cell, cell, styleSale)
 		rangeStr := fmt.Sprintf("SUM(%s)", strings.Join(saleCells, "+"))
 		style, _ = xlsx.NewStyle(`{"font":{"bold": true}}`)
@@ -221,22 +257,18 @@ func (x *XLSX) FuelSalesListNL(fsl *model.FuelSalesList) (err error) {
 	}
 
 	// Period summary row
-	startRow := strconv.Itoa(3)
-	endRow := strconv.Itoa(row - 1)
 	for _, sc := range saleCols {
-		startCell := sc + startRow
-		endCell := sc + endRow
-		cell = sc + strconv.Itoa(row)
-		rangeStr := fmt.Sprintf("SUM(%s:%s)", startCell, endCell)
+		cell = cellName(sc, row)
+		rangeStr := fmt.Sprintf("SUM(%s)", cellRange(sc, 3, sc, row-1))
 
 		xlsx.SetCellFormula(sheetNm, cell, rangeStr)
 		xlsx.SetCellStyle(sheetNm, cell, cell, style)
 	}
 
 	// Total cell
-	lastCol = toChar((int(periodLen) * 2) + 2)
-	cell = lastCol + strconv.Itoa(row)
-	rangeStr := fmt.Sprintf("SUM(%s%s:%s%s)", lastCol, startRow, lastCol, endRow)
+	totalCol := (periodLen * 2) + 2
+	cell = cellName(totalCol, row)
+	rangeStr := fmt.Sprintf("SUM(%s)", cellRange(totalCol, 3, totalCol, row-1))
 	xlsx.SetCellFormula(sheetNm, cell, rangeStr)
 	xlsx.SetCellStyle(sheetNm, cell, cell, style)
 
@@ -245,24 +277,26 @@ func (x *XLSX) FuelSalesListNL(fsl *model.FuelSalesList) (err error) {
 
 // FuelSalesListDSL method
 func (x *XLSX) FuelSalesListDSL(fsl *model.FuelSalesList) (err error) {
+	return x.FuelSalesListDSLSheet("Diesel Fuel Sales by Station", fsl)
+}
+
+// FuelSalesListDSLSheet method writes the FuelSalesListDSL report to a new sheet named sheetTitle
+func (x *XLSX) FuelSalesListDSLSheet(sheetTitle string, fsl *model.FuelSalesList) (err error) {
 
 	var cell string
 	var style int
 
 	wkColWidth := 21.00
 	xlsx := x.file
-	sheetNm := "Sheet3"
-
-	_ = xlsx.NewSheet(sheetNm)
-	xlsx.SetSheetName(sheetNm, "Diesel Fuel Sales by Station")
+	sheetNm := x.addSheet(sheetTitle)
 
 	// Merge cells to accommodate title
 	startCell := "A1"
-	endCell := toChar(len(fsl.Report.PeriodHeader)+2) + "1"
+	endCell := cellName(len(fsl.Report.PeriodHeader)+2, 1)
 	xlsx.MergeCell(sheetNm, startCell, endCell)
 
 	style, _ = xlsx.NewStyle(`{"font":{"bold":true,"size":12}}`)
-	title := fmt.Sprintf("Diesel Fuel Sales by Station - %s", fsl.Date.Format("January 2006"))
+	title := fmt.Sprintf("Diesel Fuel Sales by Station - %s", model.PeriodTitle(fsl.Date, fsl.EndDate))
 	xlsx.SetCellValue(sheetNm, startCell, title)
 	xlsx.SetCellStyle(sheetNm, startCell, endCell, style)
 
@@ -270,15 +304,15 @@ func (x *XLSX) FuelSalesListDSL(fsl *model.FuelSalesList) (err error) {
 	col := 2
 	row := 2
 	periodLen := len(fsl.Report.PeriodHeader)
-	xlsx.SetColWidth(sheetNm, "B", toChar((periodLen)+1), wkColWidth)
+	xlsx.SetColWidth(sheetNm, "B", columnName((periodLen)+1), wkColWidth)
 
-	startCell = toChar(col) + strconv.Itoa(row)
-	endCell = toChar(col+periodLen) + strconv.Itoa(row)
+	startCell = cellName(col, row)
+	endCell = cellName(col+periodLen, row)
 	style, _ = xlsx.NewStyle(`{"font":{"color": "#333333"}}`)
 	xlsx.SetCellStyle(sheetNm, startCell, endCell, style)
 
 	for _, per := range fsl.Report.PeriodHeader {
-		cell = toChar(col) + strconv.Itoa(row)
+		cell = cellName(col, row)
 		cellVal := fmt.Sprintf("%s/%s", per.StartDate, per.EndDate)
 		xlsx.SetCellValue(sheetNm, cell, cellVal)
 		col++
@@ -286,7 +320,7 @@ func (x *XLSX) FuelSalesListDSL(fsl *model.FuelSalesList) (err error) {
 
 	// Set the first and last column width
 	xlsx.SetColWidth(sheetNm, "A", "A", 11.00)
-	lastCol := toChar(periodLen + 2)
+	lastCol := columnName(periodLen + 2)
 	xlsx.SetColWidth(sheetNm, lastCol, lastCol, 14.00)
 
 	// Now we can populate
@@ -299,14 +333,14 @@ func (x *XLSX) FuelSalesListDSL(fsl *model.FuelSalesList) (err error) {
 		if sales.StationTotal["DSL"] <= 0 {
 			continue
 		}
-		cell = toChar(col) + strconv.Itoa(row)
+		cell = cellName(col, row)
 		xlsx.SetCellValue(sheetNm, cell, sales.StationName)
 		col++
 
 		// Loop through weeks
 		saleCells := make([]string, periodLen)
 		for i := range fsl.Report.PeriodHeader {
-			cell = toChar(col) + strconv.Itoa(row)
+			cell = cellName(col, row)
 			xlsx.SetCellValue(sheetNm, cell, sales.Periods[i].FuelSales["DSL"])
 			xlsx.SetCellStyle(sheetNm, cell, cell, styleSale)
 			saleCells[i] = cell
@@ -314,7 +348,7 @@ func (x *XLSX) FuelSalesListDSL(fsl *model.FuelSalesList) (err error) {
 		}
 
 		// Station summary cell
-		cell = toChar(col) + strconv.Itoa(row)
+		cell = cellName(col, row)
 		xlsx.SetCellStyle(sheetNm, cell, cell, styleSale)
 		rangeStr := fmt.Sprintf("SUM(%s)", strings.Join(saleCells, "+"))
 		style, _ = xlsx.NewStyle(`{"font":{"bold": true}}`)
@@ -326,14 +360,10 @@ func (x *XLSX) FuelSalesListDSL(fsl *model.FuelSalesList) (err error) {
 	}
 
 	// Period summary row
-	startRow := strconv.Itoa(3)
-	endRow := strconv.Itoa(row - 1)
 	col = 2
 	for i := 0; i <= periodLen; i++ {
-		cell = toChar(col) + strconv.Itoa(row)
-		startCell := toChar(col) + startRow
-		endCell := toChar(col) + endRow
-		rangeStr := fmt.Sprintf("SUM(%s:%s)", startCell, endCell)
+		cell = cellName(col, row)
+		rangeStr := fmt.Sprintf("SUM(%s)", cellRange(col, 3, col, row-1))
 		xlsx.SetCellFormula(sheetNm, cell, rangeStr)
 		xlsx.SetCellStyle(sheetNm, cell, cell, style)
 		col++
@@ -344,6 +374,11 @@ func (x *XLSX) FuelSalesListDSL(fsl *model.FuelSalesList) (err error) {
 
 // FuelDelivery method
 func (x *XLSX) FuelDelivery(fd *model.FuelDelivery) (err error) {
+	return x.FuelDeliverySheet("Fuel Delivery", fd)
+}
+
+// FuelDeliverySheet method writes the FuelDelivery report to a new sheet named sheetTitle
+func (x *XLSX) FuelDeliverySheet(sheetTitle string, fd *model.FuelDelivery) (err error) {
 
 	var cell string
 	var style int
@@ -351,18 +386,15 @@ func (x *XLSX) FuelDelivery(fd *model.FuelDelivery) (err error) {
 	numColWidth := 10.00
 
 	xlsx := x.file
-	sheetNm := "Sheet4"
-
-	_ = xlsx.NewSheet(sheetNm)
-	xlsx.SetSheetName(sheetNm, "Fuel Delivery")
+	sheetNm := x.addSheet(sheetTitle)
 
 	// Merge cells to accommodate width of all fuel types
-	endCell := toChar(len(fuelTypes)+2) + "1"
+	endCell := cellName(len(fuelTypes)+2, 1)
 	xlsx.MergeCell(sheetNm, "A1", endCell)
 
 	style, _ = xlsx.NewStyle(`{"font":{"bold":true,"size":12}}`)
 
-	title := fmt.Sprintf("%s Fuel Deliveries - %s", fd.Station.Name, fd.Date.Format("January 2006"))
+	title := fmt.Sprintf("%s Fuel Deliveries - %s", fd.Station.Name, model.PeriodTitle(fd.Date, fd.EndDate))
 	xlsx.SetCellValue(sheetNm, "A1", title)
 	xlsx.SetCellStyle(sheetNm, "A1", "A1", style)
 
@@ -373,13 +405,13 @@ func (x *XLSX) FuelDelivery(fd *model.FuelDelivery) (err error) {
 	xlsx.SetCellValue(sheetNm, "A2", "Date")
 	xlsx.SetCellStyle(sheetNm, "A2", "A2", style)
 
-	xlsx.SetColWidth(sheetNm, "B", toChar(len(fuelTypes)+1), numColWidth)
+	xlsx.SetColWidth(sheetNm, "B", columnName(len(fuelTypes)+1), numColWidth)
 
 	col := 2
 	row := 2
 	style, _ = xlsx.NewStyle(`{"font":{"bold":true}}`)
 	for _, ft := range fuelTypes {
-		cell = toChar(col) + strconv.Itoa(row)
+		cell = cellName(col, row)
 		xlsx.SetCellValue(sheetNm, cell, ft)
 		xlsx.SetCellStyle(sheetNm, cell, cell, style)
 		col++
@@ -389,19 +421,21 @@ func (x *XLSX) FuelDelivery(fd *model.FuelDelivery) (err error) {
 	col = 1
 	row = 3
 	style, _ = xlsx.NewStyle(`{"number_format": 3}`)
+	sums := make(map[string]float64, len(fuelTypes))
 
 	for _, r := range fd.Report.Deliveries {
 
 		t, _ := time.Parse(timeShortForm, strconv.Itoa(int(r.Date)))
-		cell = toChar(col) + strconv.Itoa(row)
+		cell = cellName(col, row)
 		xlsx.SetCellValue(sheetNm, cell, t.Format(dateDayFormat))
 		col++
 
 		for _, ft := range fuelTypes {
 
-			cell = toChar(col) + strconv.Itoa(row)
+			cell = cellName(col, row)
 			if r.Data[ft] > 0 {
 				xlsx.SetCellValue(sheetNm, cell, r.Data[ft])
+				sums[ft] += float64(r.Data[ft])
 			} else {
 				xlsx.SetCellValue(sheetNm, cell, "")
 			}
@@ -415,14 +449,15 @@ func (x *XLSX) FuelDelivery(fd *model.FuelDelivery) (err error) {
 
 	// Summary Row
 	style, _ = xlsx.NewStyle(`{"number_format": 3, "font":{"bold":true}}`)
-	cell = toChar(col) + strconv.Itoa(row)
+	cell = cellName(col, row)
 	xlsx.SetCellValue(sheetNm, cell, "")
 	col++
 
 	for _, ft := range fuelTypes {
-		cell = toChar(col) + strconv.Itoa(row)
-		xlsx.SetCellValue(sheetNm, cell, fd.Report.DeliverySummary[ft])
-		xlsx.SetCellStyle(sheetNm, cell, cell, style)
+		cell = cellName(col, row)
+		x.setSum(sheetNm, cell, cellRange(col, 3, col, row-1), style)
+		x.checkTotal(sheetNm, cell, sums[ft], fd.Report.DeliverySummary[ft])
+		x.recordTotal(fd, ft, sheetNm, cell, sums[ft])
 		col++
 	}
 
@@ -431,6 +466,11 @@ func (x *XLSX) FuelDelivery(fd *model.FuelDelivery) (err error) {
 
 // OverShortMonth method
 func (x *XLSX) OverShortMonth(os *model.OverShortMonth) (err error) {
+	return x.OverShortMonthSheet("Over-Short Month", os)
+}
+
+// OverShortMonthSheet method writes the OverShortMonth report to a new sheet named sheetTitle
+func (x *XLSX) OverShortMonthSheet(sheetTitle string, os *model.OverShortMonth) (err error) {
 
 	var cell string
 	var style int
@@ -438,19 +478,15 @@ func (x *XLSX) OverShortMonth(os *model.OverShortMonth) (err error) {
 	numColWidth := 10.00
 
 	xlsx := x.file
-	sheetNm := "Sheet5"
-
-	_ = xlsx.NewSheet(sheetNm)
-
-	xlsx.SetSheetName(sheetNm, "Over-Short Month")
+	sheetNm := x.addSheet(sheetTitle)
 
 	// Merge cells to accommodate width of all fuel types
-	endCell := toChar(len(fuelTypes)+2) + "1"
+	endCell := cellName(len(fuelTypes)+2, 1)
 	xlsx.MergeCell(sheetNm, "A1", endCell)
 
 	style, _ = xlsx.NewStyle(`{"font":{"bold":true,"size":12}}`)
 
-	title := fmt.Sprintf("%s Over-Short Month - %s", os.Station.Name, os.Date.Format("January 2006"))
+	title := fmt.Sprintf("%s Over-Short Month - %s", os.Station.Name, model.PeriodTitle(os.Date, os.EndDate))
 	xlsx.SetCellValue(sheetNm, "A1", title)
 	xlsx.SetCellStyle(sheetNm, "A1", "A1", style)
 
@@ -461,13 +497,13 @@ func (x *XLSX) OverShortMonth(os *model.OverShortMonth) (err error) {
 	xlsx.SetCellValue(sheetNm, "A2", "Date")
 	xlsx.SetCellStyle(sheetNm, "A2", "A2", style)
 
-	xlsx.SetColWidth(sheetNm, "B", toChar(len(fuelTypes)+1), numColWidth)
+	xlsx.SetColWidth(sheetNm, "B", columnName(len(fuelTypes)+1), numColWidth)
 
 	col := 2
 	row := 2
 	style, _ = xlsx.NewStyle(`{"font":{"bold":true}}`)
 	for _, ft := range fuelTypes {
-		cell = toChar(col) + strconv.Itoa(row)
+		cell = cellName(col, row)
 		xlsx.SetCellValue(sheetNm, cell, ft)
 		xlsx.SetCellStyle(sheetNm, cell, cell, style)
 		col++
@@ -478,11 +514,12 @@ func (x *XLSX) OverShortMonth(os *model.OverShortMonth) (err error) {
 	row = 3
 	stylePos, _ := xlsx.NewStyle(`{"number_format": 4}`)
 	styleNeg, _ := xlsx.NewStyle(`{"number_format": 4, "font":{"color": "#ff0000"}}`)
+	sums := make(map[string]float64, len(fuelTypes))
 
 	for _, r := range os.Report.OverShort {
 
 		t, _ := time.Parse(timeShortForm, strconv.Itoa(int(r.Date)))
-		cell = toChar(col) + strconv.Itoa(row)
+		cell = cellName(col, row)
 		xlsx.SetCellValue(sheetNm, cell, t.Format(dateDayFormat))
 		col++
 
@@ -493,9 +530,10 @@ func (x *XLSX) OverShortMonth(os *model.OverShortMonth) (err error) {
 			} else {
 				style = stylePos
 			}
-			cell = toChar(col) + strconv.Itoa(row)
+			cell = cellName(col, row)
 			xlsx.SetCellValue(sheetNm, cell, val)
 			xlsx.SetCellStyle(sheetNm, cell, cell, style)
+			sums[ft] += val
 			col++
 		}
 
@@ -503,54 +541,66 @@ func (x *XLSX) OverShortMonth(os *model.OverShortMonth) (err error) {
 		row++
 	}
 
-	// Summary Row
-	stylePos, _ = xlsx.NewStyle(`{"number_format": 4, "font": {"bold":true}}`)
-	styleNeg, _ = xlsx.NewStyle(`{"number_format": 4, "font":{"bold":true, "color": "#ff0000"}}`)
+	lastRow := row - 1
 
-	cell = toChar(col) + strconv.Itoa(row)
+	// Summary Row, negative totals are shown red by the number format as the rows may change
+	style, _ = xlsx.NewStyle(`{"custom_number_format": "#,##0.00;[Red]-#,##0.00", "font": {"bold":true}}`)
+
+	cell = cellName(col, row)
 	xlsx.SetCellValue(sheetNm, cell, "")
 	col++
 
 	for _, ft := range fuelTypes {
-
-		val := os.Report.OverShortSummary[ft]
-		if val < 0 {
-			style = styleNeg
-		} else {
-			style = stylePos
-		}
-		cell = toChar(col) + strconv.Itoa(row)
-		xlsx.SetCellValue(sheetNm, cell, val)
-		xlsx.SetCellStyle(sheetNm, cell, cell, style)
+		cell = cellName(col, row)
+		x.setSum(sheetNm, cell, cellRange(col, 3, col, lastRow), style)
+		x.checkTotal(sheetNm, cell, sums[ft], os.Report.OverShortSummary[ft])
+		x.recordTotal(os, ft, sheetNm, cell, sums[ft])
 		col++
 	}
 
-	return err
+	return x.addChart(chart{
+		kind:      "bar",
+		title:     "Daily Over-Short",
+		sheet:     sheetNm,
+		cell:      cellName(len(fuelTypes)+3, 2),
+		headerRow: 2,
+		firstRow:  3,
+		lastRow:   lastRow,
+		firstCol:  2,
+		lastCol:   len(fuelTypes) + 1,
+	})
 }
 
 // OverShortAnnual method
 func (x *XLSX) OverShortAnnual(os *model.OverShortAnnual) (err error) {
+	return x.OverShortAnnualSheet("Over-Short Annual", os)
+}
+
+// OverShortAnnualSheet method writes the OverShortAnnual report to a new sheet named sheetTitle
+func (x *XLSX) OverShortAnnualSheet(sheetTitle string, os *model.OverShortAnnual) (err error) {
 
 	var cell string
 	var style int
 	fuelTypes := os.Report.FuelTypes
 	numColWidth := 10.00
 	months := setMonths(os.Report.Year, len(os.Report.Months))
+	monthFrmt := "January"
+	if !os.EndDate.IsZero() {
+		// Date ranges may start mid year and cross a year end
+		months = sortedMonths(os.Report.Months)
+		monthFrmt = dateMonthFormat
+	}
 
 	xlsx := x.file
-	sheetNm := "Sheet6"
-
-	_ = xlsx.NewSheet(sheetNm)
-
-	xlsx.SetSheetName(sheetNm, "Over-Short Annual")
+	sheetNm := x.addSheet(sheetTitle)
 
 	// Merge cells to accommodate width of all fuel types
-	endCell := toChar(len(fuelTypes)+2) + "1"
+	endCell := cellName(len(fuelTypes)+2, 1)
 	xlsx.MergeCell(sheetNm, "A1", endCell)
 
 	style, _ = xlsx.NewStyle(`{"font":{"bold":true,"size":12}}`)
 
-	title := fmt.Sprintf("%s Over-Short Annual - %s", os.Station.Name, os.Date.Format("January 2006"))
+	title := fmt.Sprintf("%s Over-Short Annual - %s", os.Station.Name, model.PeriodTitle(os.Date, os.EndDate))
 	xlsx.SetCellValue(sheetNm, "A1", title)
 	xlsx.SetCellStyle(sheetNm, "A1", "A1", style)
 
@@ -561,14 +611,14 @@ func (x *XLSX) OverShortAnnual(os *model.OverShortAnnual) (err error) {
 	xlsx.SetCellValue(sheetNm, "A2", "Date")
 	xlsx.SetCellStyle(sheetNm, "A2", "A2", style)
 
-	xlsx.SetColWidth(sheetNm, "A", toChar(len(fuelTypes)+1), numColWidth)
+	xlsx.SetColWidth(sheetNm, "A", columnName(len(fuelTypes)+1), numColWidth)
 
 	col := 2
 	row := 2
 	style, _ = xlsx.NewStyle(`{"font":{"bold":true}}`)
 
 	for _, ft := range fuelTypes {
-		cell = toChar(col) + strconv.Itoa(row)
+		cell = cellName(col, row)
 		xlsx.SetCellValue(sheetNm, cell, ft)
 		xlsx.SetCellStyle(sheetNm, cell, cell, style)
 		col++
@@ -579,12 +629,13 @@ func (x *XLSX) OverShortAnnual(os *model.OverShortAnnual) (err error) {
 	row = 3
 	stylePos, _ := xlsx.NewStyle(`{"number_format": 4}`)
 	styleNeg, _ := xlsx.NewStyle(`{"number_format": 4, "font":{"color": "#ff0000"}}`)
+	sums := make(map[string]float64, len(fuelTypes))
 
 	for _, m := range months {
 
 		t, _ := time.Parse(timeMonthForm, m)
-		cell = toChar(col) + strconv.Itoa(row)
-		xlsx.SetCellValue(sheetNm, cell, t.Format("January"))
+		cell = cellName(col, row)
+		xlsx.SetCellValue(sheetNm, cell, t.Format(monthFrmt))
 		col++
 
 		for _, ft := range fuelTypes {
@@ -595,9 +646,10 @@ func (x *XLSX) OverShortAnnual(os *model.OverShortAnnual) (err error) {
 			} else {
 				style = stylePos
 			}
-			cell = toChar(col) + strconv.Itoa(row)
+			cell = cellName(col, row)
 			xlsx.SetCellValue(sheetNm, cell, val)
 			xlsx.SetCellStyle(sheetNm, cell, cell, style)
+			sums[ft] += val
 			col++
 		}
 
@@ -605,32 +657,38 @@ func (x *XLSX) OverShortAnnual(os *model.OverShortAnnual) (err error) {
 		row++
 	}
 
-	// Summary Row
-	stylePos, _ = xlsx.NewStyle(`{"number_format": 4, "font": {"bold":true}}`)
-	styleNeg, _ = xlsx.NewStyle(`{"number_format": 4, "font":{"bold":true, "color": "#ff0000"}}`)
+	lastRow := row - 1
+
+	// Summary Row, negative totals are shown red by the number format as the rows may change
+	style, _ = xlsx.NewStyle(`{"custom_number_format": "#,##0.00;[Red]-#,##0.00", "font": {"bold":true}}`)
 
-	cell = toChar(col) + strconv.Itoa(row)
+	cell = cellName(col, row)
 	xlsx.SetCellValue(sheetNm, cell, "")
 	col++
 
 	for _, ft := range fuelTypes {
-		val := os.Report.Summary[ft]
-		if val < 0 {
-			style = styleNeg
-		} else {
-			style = stylePos
-		}
-		cell = toChar(col) + strconv.Itoa(row)
-		xlsx.SetCellValue(sheetNm, cell, val)
-		xlsx.SetCellStyle(sheetNm, cell, cell, style)
+		cell = cellName(col, row)
+		x.setSum(sheetNm, cell, cellRange(col, 3, col, lastRow), style)
+		x.checkTotal(sheetNm, cell, sums[ft], os.Report.Summary[ft])
 		col++
 	}
 
-	return err
+	return x.addChart(chart{
+		kind:      "col",
+		title:     "Monthly Over-Short",
+		sheet:     sheetNm,
+		cell:      cellName(len(fuelTypes)+3, 2),
+		headerRow: 2,
+		firstRow:  3,
+		lastRow:   lastRow,
+		firstCol:  2,
+		lastCol:   len(fuelTypes) + 1,
+	})
 }
 
 // OutputFile method
 func (x *XLSX) OutputFile() (buf bytes.Buffer, err error) {
+	x.linkSummary()
 	err = x.file.Write(&buf)
 	if err != nil {
 		log.Errorf("xlsx err: %s", err)
@@ -640,16 +698,83 @@ func (x *XLSX) OutputFile() (buf bytes.Buffer, err error) {
 
 // OutputToDisk method
 func (x *XLSX) OutputToDisk(path string) (fp string, err error) {
+	x.linkSummary()
 	err = x.file.SaveAs(path)
 	return path, err
 }
 
 // ======================== Helper Methods ================================= //
 
-// see: https://stackoverflow.com/questions/36803999/golang-alphabetic-representation-of-a-number
-// for a way to map int to letters
-func toChar(i int) string {
-	return abc[i-1 : i]
+// addSheet method names the default sheet, or adds a new one once the default
+// is in use, and returns the sheet name made valid and unique within the workbook
+func (x *XLSX) addSheet(title string) string {
+
+	name := x.sheetName(title)
+	if len(x.sheets) == 0 {
+		x.file.SetSheetName(defaultSheet, name)
+	} else {
+		x.file.NewSheet(name)
+	}
+	x.sheets[strings.ToLower(name)] = true
+
+	return name
+}
+
+// sheetName method strips characters Excel doesn't allow in sheet names,
+// truncates to the maximum length and numbers any duplicates
+func (x *XLSX) sheetName(title string) string {
+
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`:\/?*[]`, r) {
+			return -1
+		}
+		return r
+	}, title)
+	name = strings.Trim(name, " '")
+	if name == "" {
+		name = "Sheet"
+	}
+
+	base := truncate(name, maxSheetName)
+	name = base
+	for n := 2; x.sheets[strings.ToLower(name)]; n++ {
+		sfx := fmt.Sprintf(" (%d)", n)
+		name = truncate(base, maxSheetName-len(sfx)) + sfx
+	}
+	return name
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) > n {
+		return strings.TrimRight(string(r[:n]), " ")
+	}
+	return s
+}
+
+// columnName function returns the A1 style name of the 1 based column i,
+// e.g. 1 is A, 27 is AA and 16384, the last column a sheet can hold, is XFD
+func columnName(i int) string {
+	if i < 1 || i > maxColumns {
+		log.Errorf("Column %d out of range 1-%d", i, maxColumns)
+		return ""
+	}
+	var name []byte
+	for ; i > 0; i = (i - 1) / 26 {
+		name = append([]byte{byte('A' + (i-1)%26)}, name...)
+	}
+	return string(name)
+}
+
+// cellName function returns the A1 style reference of the cell at col and row
+func cellName(col, row int) string {
+	return columnName(col) + strconv.Itoa(row)
+}
+
+// cellRange function returns the A1 style reference of the cells between
+// the two corners, e.g. B3:AA10
+func cellRange(startCol, startRow, endCol, endRow int) string {
+	return cellName(startCol, startRow) + ":" + cellName(endCol, endRow)
 }
 
 // Found these function at: https://stackoverflow.com/questions/18390266/how-can-we-truncate-float64-type-to-a-particular-precision-in-golang
@@ -663,6 +788,15 @@ func toFixed(num float64, precision int) float64 {
 	return float64(round(num*output)) / output
 }
 
+// sortedMonths function returns the YYYYMM keys of months in order
+func sortedMonths(months map[string]map[string]float64) (keys []string) {
+	for m := range months {
+		keys = append(keys, m)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func setMonths(year, numMonths int) (months []string) {
 	dte := time.Date(year, time.January, 1, 12, 0, 0, 0, time.UTC)
 	months = append(months, dte.Format("200601"))
@@ -1,11 +1,15 @@
 package xlsx
 
 import (
+	"context"
+	"io/ioutil"
 	"os"
+	"path"
 	"testing"
 	"time"
 
 	"github.com/pulpfree/gdps-fs-dwnld/config"
+	"github.com/pulpfree/gdps-fs-dwnld/gdpstest"
 	"github.com/pulpfree/gdps-fs-dwnld/graphql"
 	"github.com/pulpfree/gdps-fs-dwnld/model"
 	"github.com/stretchr/testify/suite"
@@ -14,7 +18,8 @@ import (
 const (
 	date             = "2018-08-01"
 	defaultsFilePath = "../config/defaults.yaml"
-	filePath         = "../tmp/testfile.xlsx"
+	fixtureDir       = "../fixture/testdata"
+	fileName         = "testfile.xlsx"
 	stationID        = "d03224a7-f1df-4863-bcaa-5c6e61af11fc"
 	timeFormat       = "2006-01-02"
 )
@@ -26,6 +31,8 @@ type Suite struct {
 	request *model.Request
 	file    *XLSX
 	graphql *graphql.Client
+	server  *gdpstest.Server
+	tmpDir  string
 }
 
 // SetupTest method
@@ -41,6 +48,12 @@ func (suite *Suite) SetupTest() {
 	suite.NoError(err)
 	suite.IsType(new(config.Config), suite.cfg)
 
+	suite.server, err = gdpstest.NewServer(fixtureDir)
+	suite.NoError(err)
+	suite.cfg.GraphqlURI = suite.server.URL
+	suite.tmpDir, err = ioutil.TempDir("", "xlsx")
+	suite.NoError(err)
+
 	suite.file, err = NewFile()
 	suite.NoError(err)
 	suite.IsType(new(XLSX), suite.file)
@@ -49,27 +62,34 @@ func (suite *Suite) SetupTest() {
 	suite.IsType(new(graphql.Client), suite.graphql)
 }
 
+// TearDownTest method
+func (suite *Suite) TearDownTest() {
+	suite.server.Close()
+	os.RemoveAll(suite.tmpDir)
+}
+
 // TestOutput method
 func (suite *Suite) TestOutput() {
 
 	// Fetch all report data
-	fs, err := suite.graphql.FuelSales()
+	ctx := context.Background()
+	fs, err := suite.graphql.FuelSales(ctx)
 	suite.NoError(err)
 	suite.IsType(new(model.FuelSales), fs)
 
-	fsl, err := suite.graphql.FuelSalesList()
+	fsl, err := suite.graphql.FuelSalesList(ctx)
 	suite.NoError(err)
 	suite.IsType(new(model.FuelSalesList), fsl)
 
-	fd, err := suite.graphql.FuelDelivery()
+	fd, err := suite.graphql.FuelDelivery(ctx)
 	suite.NoError(err)
 	suite.IsType(new(model.FuelDelivery), fd)
 
-	osm, err := suite.graphql.OverShortMonth()
+	osm, err := suite.graphql.OverShortMonth(ctx)
 	suite.NoError(err)
 	suite.IsType(new(model.OverShortMonth), osm)
 
-	osa, err := suite.graphql.OverShortAnnual()
+	osa, err := suite.graphql.OverShortAnnual(ctx)
 	suite.NoError(err)
 	suite.IsType(new(model.OverShortAnnual), osa)
 
@@ -92,7 +112,7 @@ func (suite *Suite) TestOutput() {
 	err = suite.file.OverShortAnnual(osa)
 	suite.NoError(err)
 
-	_, err = suite.file.OutputToDisk(filePath)
+	_, err = suite.file.OutputToDisk(path.Join(suite.tmpDir, fileName))
 	suite.NoError(err)
 }
 
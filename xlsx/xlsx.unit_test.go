@@ -0,0 +1,210 @@
+package xlsx
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pulpfree/gdps-fs-dwnld/model"
+	"github.com/stretchr/testify/suite"
+)
+
+// UnitSuite struct
+type UnitSuite struct {
+	suite.Suite
+	file *XLSX
+}
+
+// SetupTest method
+func (suite *UnitSuite) SetupTest() {
+	var err error
+	suite.file, err = NewFile()
+	suite.NoError(err)
+}
+
+// TestAddSheet method
+func (suite *UnitSuite) TestAddSheet() {
+	suite.Equal("Fuel Sales", suite.file.addSheet("Fuel Sales"))
+	suite.Equal(1, suite.file.file.SheetCount, "Expected the default sheet to be reused")
+
+	suite.Equal("fuel sales (2)", suite.file.addSheet("fuel sales"))
+	suite.Equal("Station 12", suite.file.addSheet("Station: 1/2"))
+
+	long := strings.Repeat("x", 40)
+	name := suite.file.addSheet(long)
+	suite.Equal(strings.Repeat("x", maxSheetName), name)
+	name = suite.file.addSheet(long)
+	suite.Equal(strings.Repeat("x", maxSheetName-4)+" (2)", name)
+	suite.Equal(5, suite.file.file.SheetCount)
+}
+
+// TestColumnName method
+func (suite *UnitSuite) TestColumnName() {
+	cols := map[int]string{1: "A", 26: "Z", 27: "AA", 52: "AZ", 53: "BA", 702: "ZZ", 703: "AAA", 16384: "XFD"}
+	for i, name := range cols {
+		suite.Equal(name, columnName(i), "column %d", i)
+	}
+	suite.Equal("", columnName(0))
+	suite.Equal("", columnName(maxColumns+1))
+}
+
+// TestCellRange method
+func (suite *UnitSuite) TestCellRange() {
+	suite.Equal("AB12", cellName(28, 12))
+	suite.Equal("B3:AA10", cellRange(2, 3, 27, 10))
+}
+
+// TestChartRefs method
+func (suite *UnitSuite) TestChartRefs() {
+	suite.Equal("$B$3:$E$33", absRange(2, 3, 5, 33))
+	suite.Equal("$AA$2", absRange(27, 2, 27, 2))
+	suite.Equal("'Fuel Sales'!$B$2", sheetRef("Fuel Sales", "$B$2"))
+	suite.Equal("'Joe''s'!$A$1", sheetRef("Joe's", "$A$1"))
+}
+
+// TestAddChart method
+func (suite *UnitSuite) TestAddChart() {
+	c := chart{kind: "line", sheet: suite.file.addSheet("Fuel Sales"), cell: "G2", headerRow: 2, firstRow: 3, lastRow: 5, firstCol: 2, lastCol: 3}
+
+	suite.file.SetCharts(false)
+	suite.NoError(suite.file.addChart(c))
+	suite.NotContains(suite.file.file.XLSX, "xl/charts/chart1.xml", "Expected charts to be off")
+
+	suite.file.SetCharts(true)
+	empty := c
+	empty.lastRow = empty.firstRow - 1
+	suite.NoError(suite.file.addChart(empty))
+	suite.NotContains(suite.file.file.XLSX, "xl/charts/chart1.xml", "Expected no chart without data rows")
+
+	suite.NoError(suite.file.addChart(c))
+	suite.Contains(suite.file.file.XLSX, "xl/charts/chart1.xml")
+	suite.Contains(string(suite.file.file.XLSX["xl/charts/chart1.xml"]), "Fuel Sales&#39;!$C$3:$C$5")
+}
+
+// TestSummaryFormulas method
+func (suite *UnitSuite) TestSummaryFormulas() {
+	fs := new(model.FuelSales)
+	suite.NoError(json.Unmarshal([]byte(`{"fuelSaleMonth": {
+		"stationSales": [{"date": 20180801, "sales": {"NL": 100.5, "DSL": 20}}, {"date": 20180802, "sales": {"NL": 50, "DSL": 30}}],
+		"salesSummary": {"NL": 150.5, "DSL": 60},
+		"salesTotal": 210.5,
+		"fuelTypes": ["NL", "DSL"]
+	}}`), fs))
+
+	suite.NoError(suite.file.FuelSales(fs))
+	sheet := "Fuel Sales"
+	suite.Equal("SUM(B3:B4)", suite.file.file.GetCellFormula(sheet, "B5"))
+	suite.Equal("SUM(C3:C4)", suite.file.file.GetCellFormula(sheet, "C5"))
+	suite.Equal("SUM(B5:C5)", suite.file.file.GetCellFormula(sheet, "C7"))
+
+	// DSL rows add up to 50, not the 60 reported, so the grand total is out too
+	comments := suite.comments(sheet)
+	suite.Len(comments, 2)
+	suite.Contains(comments["C5"], "The rows total 50.00, the report summary is 60.00")
+	suite.Contains(comments, "C7")
+}
+
+// TestSummaryLinks method
+func (suite *UnitSuite) TestSummaryLinks() {
+	srs := make([]*model.StationReport, 2)
+	for i := range srs {
+		fs := new(model.FuelSales)
+		suite.NoError(json.Unmarshal([]byte(`{"fuelSaleMonth": {
+			"stationSales": [{"date": 20180801, "sales": {"NL": 100.5, "DSL": 20}}, {"date": 20180802, "sales": {"NL": 50, "DSL": 30}}],
+			"salesSummary": {"NL": 150.5, "DSL": 50},
+			"salesTotal": 200.5,
+			"fuelTypes": ["NL", "DSL"]
+		}}`), fs))
+		fs.Station.Name = fmt.Sprintf("Station %d", i+1)
+		srs[i] = &model.StationReport{FuelSales: fs}
+	}
+
+	// Give the summary an API figure the first station's rows don't add up to,
+	// leaving its station sheet unflagged
+	srs[0].FuelSales.Report.SalesSummary["DSL"] = 60
+	suite.NoError(suite.file.GroupSummary("Group", srs))
+	srs[0].FuelSales.Report.SalesSummary["DSL"] = 50
+	for _, sr := range srs {
+		suite.NoError(suite.file.FuelSalesSheet(sr.FuelSales.Station.Name+" Sales", sr.FuelSales))
+	}
+	_, err := suite.file.OutputFile()
+	suite.NoError(err)
+
+	// Fuel types are sorted NL first, each station has a row from row 4
+	suite.Equal("'Station 1 Sales'!B5", suite.file.file.GetCellFormula("Summary", "B4"))
+	suite.Equal("'Station 1 Sales'!C5", suite.file.file.GetCellFormula("Summary", "C4"))
+	suite.Equal("'Station 2 Sales'!C5", suite.file.file.GetCellFormula("Summary", "C5"))
+
+	suite.Equal(map[string]string{"C4": totalAuthor + "The rows total 50.00, the report summary is 60.00"}, suite.comments("Summary"))
+}
+
+// TestCheckTotal method
+func (suite *UnitSuite) TestCheckTotal() {
+	sheet := suite.file.addSheet("Totals")
+	suite.file.checkTotal(sheet, "B5", 100.004, 100)
+	suite.Empty(suite.comments(sheet), "Expected rounding to be allowed for")
+	suite.file.checkTotal(sheet, "B5", -3.5, 3.5)
+	suite.Equal(map[string]string{"B5": totalAuthor + "The rows total -3.50, the report summary is 3.50"}, suite.comments(sheet))
+}
+
+// comments method returns the text of each comment on sheet by cell
+func (suite *UnitSuite) comments(sheet string) map[string]string {
+	res := make(map[string]string)
+	for _, c := range suite.file.file.GetComments()[sheet] {
+		res[c.Ref] = c.Text
+	}
+	return res
+}
+
+// TestFuelSalesListWide method
+func (suite *UnitSuite) TestFuelSalesListWide() {
+	weeks := 20
+	fsl := &model.FuelSalesList{Date: time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)}
+	sales := model.StationPeriodSales{
+		StationID:    "1",
+		StationName:  "Wide Station",
+		StationTotal: map[string]float64{"NL": 200, "DSL": 100},
+	}
+	sales.FuelPrices.Prices = make(map[string]float64)
+	for i := 0; i < weeks; i++ {
+		yw := fmt.Sprintf("2018%02d", i+1)
+		fsl.Report.PeriodHeader = append(fsl.Report.PeriodHeader, model.PeriodHeader{YearWeek: yw})
+		sales.FuelPrices.Prices[yw] = 1.1
+		sales.Periods = append(sales.Periods, model.PeriodSales{FuelSales: map[string]float64{"NL": 10, "DSL": 5}})
+	}
+	fsl.Report.PeriodSales = []model.StationPeriodSales{sales}
+
+	suite.NoError(suite.file.FuelSalesListNLSheet("NL", fsl))
+	suite.NoError(suite.file.FuelSalesListDSLSheet("DSL", fsl))
+
+	// NL has a sale and price column per week followed by the station total
+	suite.Equal("SUM(AP3:AP3)", suite.file.file.GetCellFormula("NL", "AP4"))
+	suite.Equal("SUM(V3:V3)", suite.file.file.GetCellFormula("DSL", "V4"))
+}
+
+// TestRegisterSection method
+func (suite *UnitSuite) TestRegisterSection() {
+	sr := &model.StationReport{FuelSales: &model.FuelSales{}}
+	model.RegisterSection(model.Section{Name: "testSection", Title: "Test Section", Data: model.DataFuelSales})
+	suite.Error(suite.file.WriteSection("testSection", "", sr), "Expected an error without a workbook layout")
+
+	RegisterSection("testSection", func(x *XLSX, sheetTitle string, sr *model.StationReport) error {
+		x.addSheet(sheetTitle)
+		return nil
+	})
+	defer func() {
+		rendersMu.Lock()
+		delete(renders, "testSection")
+		rendersMu.Unlock()
+	}()
+
+	suite.NoError(suite.file.WriteSection("testSection", "", sr))
+	suite.True(suite.file.sheets["test section"])
+}
+
+// TestUnitSuite function
+func TestUnitSuite(t *testing.T) {
+	suite.Run(t, new(UnitSuite))
+}